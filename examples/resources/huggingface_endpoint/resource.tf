@@ -0,0 +1,52 @@
+# Replacing an endpoint, for example to move it to another region, creates the new endpoint and
+# waits for it to be healthy before the old one is destroyed. The name is generated from
+# name_prefix so that both endpoints can exist side by side during the rollout.
+resource "huggingface_endpoint" "blue_green" {
+  name_prefix = "embeddings-"
+
+  compute = {
+    accelerator   = "cpu"
+    instance_size = "x2"
+    instance_type = "intel-icl"
+    scaling = {
+      min_replica = 1
+      max_replica = 2
+    }
+  }
+
+  model = {
+    image = {
+      huggingface = {
+        env = {}
+      }
+    }
+    repository = "sentence-transformers/all-MiniLM-L6-v2"
+    task       = "sentence-embeddings"
+  }
+
+  cloud = {
+    region = "us-east-1"
+    vendor = "aws"
+  }
+
+  type = "protected"
+
+  health_check = {
+    path = "/health"
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+# Consumers read the url of the endpoint from status.url, so they switch to the new endpoint in
+# the same apply, before the old endpoint is destroyed.
+data "huggingface_inference" "blue_green" {
+  url     = huggingface_endpoint.blue_green.status.url
+  payload = jsonencode({ inputs = "Hello world" })
+}
+
+output "blue_green_url" {
+  value = huggingface_endpoint.blue_green.status.url
+}
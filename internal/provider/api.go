@@ -0,0 +1,362 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+// providerData is handed to resources and data sources when the provider is configured.
+type providerData struct {
+	httpClient      *http.Client
+	hub             *apiClient
+	namespaces      *namespaceClients
+	failureLogLines int
+	strictFitCheck  bool
+	defaultTags     []string
+	guardrails      guardrails
+	budget          *budget
+	catalogues      *computeCatalogueCache
+
+	endpointDefaults EndpointDefaults
+}
+
+// apiClient performs raw requests against the parts of the inference endpoints API
+// that the huggingface client does not cover, and against the Hub API.
+type apiClient struct {
+	host       string
+	namespace  string
+	token      string
+	httpClient *http.Client
+}
+
+// namespaceClients creates the clients of a namespace on first use, so that resources and data
+// sources can manage endpoints outside the namespace of the provider.
+type namespaceClients struct {
+	host             string
+	token            string
+	defaultNamespace string
+	httpClient       *http.Client
+
+	mu      sync.Mutex
+	clients map[string]*huggingface.Client
+	apis    map[string]*apiClient
+}
+
+// get returns the clients of namespace, or of the namespace of the provider when it is empty.
+func (c *namespaceClients) get(namespace string) (*huggingface.Client, *apiClient, error) {
+	if namespace == "" {
+		namespace = c.defaultNamespace
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.clients[namespace]; ok {
+		return client, c.apis[namespace], nil
+	}
+
+	host := c.host
+	token := c.token
+	client, err := huggingface.NewClient(&host, &namespace, &token)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.clients[namespace] = client
+	c.apis[namespace] = &apiClient{
+		host:       c.host,
+		namespace:  namespace,
+		token:      c.token,
+		httpClient: c.httpClient,
+	}
+	return client, c.apis[namespace], nil
+}
+
+type apiError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+// The types below extend the request and response types of the huggingface client with the
+// parts of the API it does not model.
+
+type endpointDetails struct {
+	huggingface.EndpointDetails
+	Compute endpointCompute  `json:"compute"`
+	Model   endpointModel    `json:"model"`
+	Private *endpointPrivate `json:"private,omitempty"`
+	Tags    []string         `json:"tags"`
+}
+
+type createEndpointRequest struct {
+	huggingface.CreateEndpointRequest
+	Compute endpointCompute  `json:"compute"`
+	Model   endpointModel    `json:"model"`
+	Private *endpointPrivate `json:"private,omitempty"`
+	Tags    []string         `json:"tags,omitempty"`
+}
+
+type updateEndpointRequest struct {
+	huggingface.UpdateEndpointRequest
+	Compute *endpointCompute `json:"compute,omitempty"`
+	Model   *endpointModel   `json:"model,omitempty"`
+	Tags    []string         `json:"tags"`
+}
+
+type endpointCompute struct {
+	huggingface.Compute
+	Scaling endpointScaling `json:"scaling"`
+}
+
+type endpointScaling struct {
+	huggingface.Scaling
+	Measure *scalingMeasure `json:"measure,omitempty"`
+	Metric  *string         `json:"metric,omitempty"`
+}
+
+type scalingMeasure struct {
+	HardwareUsage   *float64 `json:"hardwareUsage,omitempty"`
+	PendingRequests *float64 `json:"pendingRequests,omitempty"`
+}
+
+// endpointPrivate configures the private link between an endpoint and a cloud account.
+type endpointPrivate struct {
+	AccountId string `json:"accountId"`
+	Shared    bool   `json:"shared"`
+}
+
+type endpointModel struct {
+	huggingface.Model
+	Image endpointImage `json:"image"`
+}
+
+type endpointImage struct {
+	huggingface.Image
+	Custom   *customImage   `json:"custom,omitempty"`
+	Tgi      *tgiImage      `json:"tgi,omitempty"`
+	Tei      *teiImage      `json:"tei,omitempty"`
+	Vllm     *vllmImage     `json:"vllm,omitempty"`
+	Llamacpp *llamacppImage `json:"llamacpp,omitempty"`
+}
+
+type customImage struct {
+	huggingface.Custom
+	Args    []string `json:"args,omitempty"`
+	Command []string `json:"command,omitempty"`
+}
+
+type tgiImage struct {
+	DisableCustomKernels  *bool   `json:"disableCustomKernels,omitempty"`
+	HealthRoute           *string `json:"healthRoute,omitempty"`
+	MaxBatchPrefillTokens *int    `json:"maxBatchPrefillTokens,omitempty"`
+	MaxBatchTotalTokens   *int    `json:"maxBatchTotalTokens,omitempty"`
+	MaxInputLength        *int    `json:"maxInputLength,omitempty"`
+	MaxTotalTokens        *int    `json:"maxTotalTokens,omitempty"`
+	Port                  *int    `json:"port,omitempty"`
+	Quantize              *string `json:"quantize,omitempty"`
+	URL                   string  `json:"url"`
+}
+
+type teiImage struct {
+	HealthRoute           *string `json:"healthRoute,omitempty"`
+	MaxBatchTokens        *int    `json:"maxBatchTokens,omitempty"`
+	MaxConcurrentRequests *int    `json:"maxConcurrentRequests,omitempty"`
+	Pooling               *string `json:"pooling,omitempty"`
+	Port                  *int    `json:"port,omitempty"`
+	URL                   string  `json:"url"`
+}
+
+type vllmImage struct {
+	HealthRoute         *string `json:"healthRoute,omitempty"`
+	KvCacheDtype        *string `json:"kvCacheDtype,omitempty"`
+	MaxModelLen         *int    `json:"maxModelLen,omitempty"`
+	MaxNumBatchedTokens *int    `json:"maxNumBatchedTokens,omitempty"`
+	MaxNumSeqs          *int    `json:"maxNumSeqs,omitempty"`
+	Port                *int    `json:"port,omitempty"`
+	TensorParallelSize  *int    `json:"tensorParallelSize,omitempty"`
+	URL                 string  `json:"url"`
+}
+
+type llamacppImage struct {
+	CtxSize     *int    `json:"ctxSize,omitempty"`
+	Embeddings  *bool   `json:"embeddings,omitempty"`
+	HealthRoute *string `json:"healthRoute,omitempty"`
+	ModelPath   string  `json:"modelPath"`
+	NParallel   *int    `json:"nParallel,omitempty"`
+	Pooling     *string `json:"pooling,omitempty"`
+	Port        *int    `json:"port,omitempty"`
+	ThreadsHttp *int    `json:"threadsHttp,omitempty"`
+	URL         string  `json:"url"`
+}
+
+// computeInstance is an entry of the compute catalogue of a cloud region. Memory sizes are
+// totals for the instance.
+type computeInstance struct {
+	Accelerator  string  `json:"accelerator"`
+	InstanceType string  `json:"instanceType"`
+	InstanceSize string  `json:"instanceSize"`
+	NumGpus      int     `json:"numGpus"`
+	GpuMemoryGb  float64 `json:"gpuMemoryGb"`
+	MemoryGb     float64 `json:"memoryGb"`
+	PricePerHour float64 `json:"pricePerHour"`
+}
+
+func (c *apiClient) namespaceURL() string {
+	return strings.TrimSuffix(c.host, "/") + "/" + url.PathEscape(c.namespace)
+}
+
+func (c *apiClient) endpointURL(name string, elem ...string) string {
+	path := append([]string{c.namespace, name}, elem...)
+	for i := range path {
+		path[i] = url.PathEscape(path[i])
+	}
+	return strings.TrimSuffix(c.host, "/") + "/" + strings.Join(path, "/")
+}
+
+// send performs an authenticated request and returns the status code and body of the response.
+func (c *apiClient) send(ctx context.Context, method string, rawURL string, body io.Reader) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+// do performs an authenticated request and fails on any non-2xx response.
+func (c *apiClient) do(ctx context.Context, method string, rawURL string, body io.Reader) ([]byte, error) {
+	statusCode, respBody, err := c.send(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return nil, &apiError{StatusCode: statusCode, Body: string(respBody)}
+	}
+	return respBody, nil
+}
+
+// doJSON performs a request with an optional JSON body and decodes the JSON response into out.
+func (c *apiClient) doJSON(ctx context.Context, method string, rawURL string, in any, out any) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	respBody, err := c.do(ctx, method, rawURL, body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(respBody, out)
+}
+
+func (c *apiClient) getEndpoint(ctx context.Context, name string) (endpointDetails, error) {
+	var endpoint endpointDetails
+	err := c.doJSON(ctx, http.MethodGet, c.endpointURL(name), nil, &endpoint)
+	return endpoint, err
+}
+
+func (c *apiClient) createEndpoint(ctx context.Context, req createEndpointRequest) (endpointDetails, error) {
+	var endpoint endpointDetails
+	err := c.doJSON(ctx, http.MethodPost, c.namespaceURL(), req, &endpoint)
+	return endpoint, err
+}
+
+func (c *apiClient) updateEndpoint(ctx context.Context, name string, req updateEndpointRequest) (endpointDetails, error) {
+	var endpoint endpointDetails
+	err := c.doJSON(ctx, http.MethodPut, c.endpointURL(name), req, &endpoint)
+	return endpoint, err
+}
+
+// computeCatalogueURL returns the url of the compute catalogue of a region, which lives next to
+// the endpoint API.
+func (c *apiClient) computeCatalogueURL(vendor string, region string) string {
+	base := strings.TrimSuffix(strings.TrimSuffix(c.host, "/"), "/endpoint")
+	return base + "/provider/" + url.PathEscape(vendor) + "/regions/" + url.PathEscape(region) + "/compute"
+}
+
+func (c *apiClient) getComputeCatalogue(ctx context.Context, vendor string, region string) ([]computeInstance, error) {
+	var catalogue struct {
+		Items []computeInstance `json:"items"`
+	}
+	err := c.doJSON(ctx, http.MethodGet, c.computeCatalogueURL(vendor, region), nil, &catalogue)
+	return catalogue.Items, err
+}
+
+// computeCatalogueCache keeps the compute catalogues read by the provider, which are the same for
+// every endpoint of a region.
+type computeCatalogueCache struct {
+	api *apiClient
+
+	mu         sync.Mutex
+	catalogues map[string][]computeInstance
+}
+
+func (c *computeCatalogueCache) getComputeInstance(ctx context.Context, vendor string, region string, accelerator string, instanceType string, instanceSize string) (computeInstance, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := vendor + "/" + region
+	catalogue, ok := c.catalogues[key]
+	if !ok {
+		var err error
+		catalogue, err = c.api.getComputeCatalogue(ctx, vendor, region)
+		if err != nil {
+			return computeInstance{}, err
+		}
+		c.catalogues[key] = catalogue
+	}
+
+	for _, instance := range catalogue {
+		if instance.Accelerator == accelerator && instance.InstanceType == instanceType && instance.InstanceSize == instanceSize {
+			return instance, nil
+		}
+	}
+	return computeInstance{}, fmt.Errorf("no %s %s %s instance in %s %s", accelerator, instanceType, instanceSize, vendor, region)
+}
+
+// endpointLogs returns at most the last lines of the container logs of the named endpoint.
+func (c *apiClient) endpointLogs(ctx context.Context, name string, lines int) (string, error) {
+	logs, err := c.do(ctx, http.MethodGet, c.endpointURL(name, "logs"), nil)
+	if err != nil {
+		return "", err
+	}
+	return tailLines(string(logs), lines), nil
+}
+
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
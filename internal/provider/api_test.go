@@ -0,0 +1,26 @@
+package provider
+
+import "testing"
+
+func TestTailLines(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		n    int
+		want string
+	}{
+		{name: "empty", s: "", n: 3, want: ""},
+		{name: "fewer lines", s: "a\nb", n: 3, want: "a\nb"},
+		{name: "exact lines", s: "a\nb\nc", n: 3, want: "a\nb\nc"},
+		{name: "more lines", s: "a\nb\nc\nd\ne", n: 2, want: "d\ne"},
+		{name: "trailing newlines", s: "a\nb\nc\n\n", n: 2, want: "b\nc"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := tailLines(test.s, test.n); got != test.want {
+				t.Errorf("tailLines(%q, %d) = %q, want %q", test.s, test.n, got, test.want)
+			}
+		})
+	}
+}
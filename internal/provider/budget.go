@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// budget enforces the hourly cost limits of the provider. Terraform plans every endpoint of a
+// configuration in the same provider process, so the costs planned so far are kept to check the
+// total against.
+type budget struct {
+	maxPerEndpoint *float64
+	maxTotal       *float64
+
+	mu       sync.Mutex
+	costs    map[string]endpointCost
+	replaced map[string][]string
+	created  int
+}
+
+// endpointCost is the hourly cost of an endpoint running at its maximum number of replicas.
+type endpointCost struct {
+	name       string
+	instance   computeInstance
+	maxReplica int
+}
+
+func (c endpointCost) hourly() float64 {
+	return c.instance.PricePerHour * float64(c.maxReplica)
+}
+
+func (c endpointCost) String() string {
+	return fmt.Sprintf(
+		"%s: %d x %s %s %s at $%.2f/h = $%.2f/h",
+		c.name, c.maxReplica, c.instance.Accelerator, c.instance.InstanceType, c.instance.InstanceSize, c.instance.PricePerHour, c.hourly(),
+	)
+}
+
+func (b *budget) enabled() bool {
+	return b.maxPerEndpoint != nil || b.maxTotal != nil
+}
+
+// add records the cost of an endpoint in state under key, the name it has in state, and returns
+// the costs of all the endpoints planned so far. When the plan replaces the endpoint, replacement
+// identifies the endpoint that replaces it.
+func (b *budget) add(key string, replacement string, cost endpointCost) []endpointCost {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if replacement != "" && !slices.Contains(b.replaced[replacement], key) {
+		b.replaced[replacement] = append(b.replaced[replacement], key)
+	}
+	b.costs[key] = cost
+	return b.sortedCosts()
+}
+
+// addNew records the cost of an endpoint planned without state, identified by replacement, and
+// returns the costs of all the endpoints planned so far.
+//
+// The create of a replacement is planned without state after the endpoint it replaces, and takes
+// its place so that both are not counted. Any other new endpoint is counted on its own, even with
+// the same identity as another one, as the instances of a resource with count and name_prefix.
+func (b *budget) addNew(replacement string, cost endpointCost) []endpointCost {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var key string
+	if replaced := b.replaced[replacement]; len(replaced) > 0 {
+		key = replaced[0]
+		b.replaced[replacement] = replaced[1:]
+	} else {
+		b.created++
+		key = fmt.Sprintf("+%d", b.created)
+	}
+	b.costs[key] = cost
+	return b.sortedCosts()
+}
+
+// sortedCosts returns the costs recorded so far, sorted by name.
+func (b *budget) sortedCosts() []endpointCost {
+	costs := make([]endpointCost, 0, len(b.costs))
+	for _, c := range b.costs {
+		costs = append(costs, c)
+	}
+	sort.Slice(costs, func(i, j int) bool {
+		if costs[i].name != costs[j].name {
+			return costs[i].name < costs[j].name
+		}
+		return costs[i].String() < costs[j].String()
+	})
+	return costs
+}
+
+// costBreakdown lists the costs one endpoint per line, followed by their total.
+func costBreakdown(costs []endpointCost) (string, float64) {
+	var lines []string
+	var total float64
+	for _, cost := range costs {
+		lines = append(lines, "  "+cost.String())
+		total += cost.hourly()
+	}
+	lines = append(lines, fmt.Sprintf("  total: $%.2f/h", total))
+	return strings.Join(lines, "\n"), total
+}
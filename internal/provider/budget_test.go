@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func testBudget() *budget {
+	return &budget{
+		costs:    map[string]endpointCost{},
+		replaced: map[string][]string{},
+	}
+}
+
+func testEndpointCost(name string, price float64, maxReplica int) endpointCost {
+	return endpointCost{
+		name:       name,
+		instance:   computeInstance{Accelerator: "gpu", InstanceType: "nvidia-a10g", InstanceSize: "x1", PricePerHour: price},
+		maxReplica: maxReplica,
+	}
+}
+
+func costNames(costs []endpointCost) []string {
+	names := make([]string, 0, len(costs))
+	for _, cost := range costs {
+		names = append(names, cost.name)
+	}
+	return names
+}
+
+func TestBudgetAdd(t *testing.T) {
+	// add is the plan of an endpoint, in state under key when it is set
+	type add struct {
+		key         string
+		replacement string
+		name        string
+	}
+	tests := []struct {
+		name  string
+		adds  []add
+		want  []string
+		total float64
+	}{
+		{
+			name:  "endpoints in state",
+			adds:  []add{{key: "team/b", name: "team/b"}, {key: "team/a", name: "team/a"}},
+			want:  []string{"team/a", "team/b"},
+			total: 2,
+		},
+		{
+			name:  "endpoint in state planned twice",
+			adds:  []add{{key: "team/a", name: "team/a"}, {key: "team/a", name: "team/a"}},
+			want:  []string{"team/a"},
+			total: 1,
+		},
+		{
+			name: "new endpoints with count and name_prefix",
+			adds: []add{
+				{replacement: "team/web* x1", name: "team/web*"},
+				{replacement: "team/web* x1", name: "team/web*"},
+				{replacement: "team/web* x1", name: "team/web*"},
+			},
+			want:  []string{"team/web*", "team/web*", "team/web*"},
+			total: 3,
+		},
+		{
+			name: "replacement planned after the endpoint it replaces",
+			adds: []add{
+				{key: "team/web-1234", replacement: "team/web* x1", name: "team/web-1234"},
+				{replacement: "team/web* x1", name: "team/web*"},
+			},
+			want:  []string{"team/web*"},
+			total: 1,
+		},
+		{
+			name: "replacement planned next to a new endpoint with the same identity",
+			adds: []add{
+				{key: "team/web-1234", replacement: "team/web* x1", name: "team/web-1234"},
+				{replacement: "team/web* x1", name: "team/web*"},
+				{replacement: "team/web* x1", name: "team/web*"},
+			},
+			want:  []string{"team/web*", "team/web*"},
+			total: 2,
+		},
+		{
+			name: "new endpoint next to an endpoint in state that is not replaced",
+			adds: []add{
+				{key: "team/web-1234", name: "team/web-1234"},
+				{replacement: "team/web* x1", name: "team/web*"},
+			},
+			want:  []string{"team/web*", "team/web-1234"},
+			total: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := testBudget()
+			var costs []endpointCost
+			for _, add := range test.adds {
+				cost := testEndpointCost(add.name, 1, 1)
+				if add.key == "" {
+					costs = b.addNew(add.replacement, cost)
+				} else {
+					costs = b.add(add.key, add.replacement, cost)
+				}
+			}
+
+			got := costNames(costs)
+			if !slices.Equal(got, test.want) {
+				t.Fatalf("add() = %v, want %v", got, test.want)
+			}
+			if _, total := costBreakdown(costs); total != test.total {
+				t.Errorf("costBreakdown() total = %v, want %v", total, test.total)
+			}
+		})
+	}
+}
+
+func TestEndpointCostHourly(t *testing.T) {
+	cost := testEndpointCost("team/a", 1.5, 4)
+	if got := cost.hourly(); got != 6 {
+		t.Errorf("hourly() = %v, want 6", got)
+	}
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &endpointHealthDataSource{}
+	_ datasource.DataSourceWithConfigure = &endpointHealthDataSource{}
+)
+
+func NewEndpointHealthDataSource() datasource.DataSource {
+	return &endpointHealthDataSource{}
+}
+
+type endpointHealthDataSource struct {
+	namespaces *namespaceClients
+	guardrails guardrails
+}
+
+type endpointHealthDataSourceModel struct {
+	Name          types.String `tfsdk:"name"`
+	Namespace     types.String `tfsdk:"namespace"`
+	State         types.String `tfsdk:"state"`
+	ReadyReplica  types.Int64  `tfsdk:"ready_replica"`
+	TargetReplica types.Int64  `tfsdk:"target_replica"`
+	MinReplica    types.Int64  `tfsdk:"min_replica"`
+	MaxReplica    types.Int64  `tfsdk:"max_replica"`
+	UpdatedAt     types.String `tfsdk:"updated_at"`
+	ErrorMessage  types.String `tfsdk:"error_message"`
+}
+
+func (d *endpointHealthDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected data source configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	d.namespaces = data.namespaces
+	d.guardrails = data.guardrails
+}
+
+func (d *endpointHealthDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_endpoint_health"
+}
+
+func (d *endpointHealthDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"namespace": schema.StringAttribute{
+				Optional: true,
+			},
+			"state": schema.StringAttribute{
+				Computed: true,
+			},
+			"ready_replica": schema.Int64Attribute{
+				Computed: true,
+			},
+			"target_replica": schema.Int64Attribute{
+				Computed: true,
+			},
+			"min_replica": schema.Int64Attribute{
+				Computed: true,
+			},
+			"max_replica": schema.Int64Attribute{
+				Computed: true,
+			},
+			"updated_at": schema.StringAttribute{
+				Computed: true,
+			},
+			"error_message": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *endpointHealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config endpointHealthDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace := d.namespaces.defaultNamespace
+	if !config.Namespace.IsNull() {
+		namespace = config.Namespace.ValueString()
+	}
+	d.guardrails.checkNamespace(&resp.Diagnostics, path.Root("namespace"), namespace)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, _, err := d.namespaces.get(namespace)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"unable to create huggingface api client",
+			err.Error(),
+		)
+		return
+	}
+
+	endpoint, err := client.GetEndpoint(config.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error reading endpoint",
+			"could not read endpoint named "+config.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	d.guardrails.checkVendor(&resp.Diagnostics, path.Root("name"), endpoint.Provider.Vendor)
+	d.guardrails.checkRegion(&resp.Diagnostics, path.Root("name"), endpoint.Provider.Region)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.State = types.StringValue(endpoint.Status.State)
+	config.ReadyReplica = types.Int64Value(int64(endpoint.Status.ReadyReplica))
+	config.TargetReplica = types.Int64Value(int64(endpoint.Status.TargetReplica))
+	config.MinReplica = types.Int64Value(int64(endpoint.Compute.Scaling.MinReplica))
+	config.MaxReplica = types.Int64Value(int64(endpoint.Compute.Scaling.MaxReplica))
+	config.UpdatedAt = types.StringValue(endpoint.Status.UpdatedAt)
+	config.ErrorMessage = types.StringValue(endpoint.Status.ErrorMessage)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// guardrails restricts the namespaces, cloud vendors and regions the provider may manage
+// endpoints in. An empty list allows everything.
+type guardrails struct {
+	allowedNamespaces []string
+	allowedRegions    []string
+	allowedVendors    []string
+}
+
+func (g guardrails) enabled() bool {
+	return len(g.allowedNamespaces) > 0 || len(g.allowedRegions) > 0 || len(g.allowedVendors) > 0
+}
+
+func (g guardrails) checkNamespace(diags *diag.Diagnostics, attributePath path.Path, namespace string) {
+	checkAllowed(diags, attributePath, "namespace", namespace, "allowed_namespaces", g.allowedNamespaces)
+}
+
+func (g guardrails) checkVendor(diags *diag.Diagnostics, attributePath path.Path, vendor string) {
+	checkAllowed(diags, attributePath, "vendor", vendor, "allowed_vendors", g.allowedVendors)
+}
+
+func (g guardrails) checkRegion(diags *diag.Diagnostics, attributePath path.Path, region string) {
+	checkAllowed(diags, attributePath, "region", region, "allowed_regions", g.allowedRegions)
+}
+
+func checkAllowed(diags *diag.Diagnostics, attributePath path.Path, kind string, value string, setting string, allowed []string) {
+	if len(allowed) == 0 || slices.Contains(allowed, value) {
+		return
+	}
+	diags.AddAttributeError(
+		attributePath,
+		kind+" not allowed",
+		fmt.Sprintf("%s %s is not in the %s of the provider: %s", kind, value, setting, strings.Join(allowed, ", ")),
+	)
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestCheckAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		allowed []string
+		wantErr bool
+	}{
+		{name: "no restriction", value: "aws", allowed: nil},
+		{name: "allowed", value: "aws", allowed: []string{"azure", "aws"}},
+		{name: "not allowed", value: "gcp", allowed: []string{"azure", "aws"}, wantErr: true},
+		{name: "case sensitive", value: "AWS", allowed: []string{"aws"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			attributePath := path.Root("cloud").AtName("vendor")
+			checkAllowed(&diags, attributePath, "vendor", test.value, "allowed_vendors", test.allowed)
+			if diags.HasError() != test.wantErr {
+				t.Fatalf("checkAllowed() diagnostics = %v, want error %t", diags, test.wantErr)
+			}
+			if !test.wantErr {
+				return
+			}
+			errs := diags.Errors()
+			withPath, ok := errs[0].(diag.DiagnosticWithPath)
+			if !ok || !withPath.Path().Equal(attributePath) {
+				t.Errorf("checkAllowed() error %v is not attached to %s", errs[0], attributePath)
+			}
+			if summary := errs[0].Summary(); summary != "vendor not allowed" {
+				t.Errorf("checkAllowed() summary = %q, want %q", summary, "vendor not allowed")
+			}
+		})
+	}
+}
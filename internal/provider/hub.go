@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	defaultHubHost       = "https://huggingface.co"
+	defaultModelRevision = "main"
+)
+
+// hubModel is the model information returned by the Hub model API.
+type hubModel struct {
+	ID          string   `json:"id"`
+	SHA         string   `json:"sha"`
+	PipelineTag string   `json:"pipeline_tag"`
+	LibraryName string   `json:"library_name"`
+	Private     bool     `json:"private"`
+	Gated       any      `json:"gated"`
+	Tags        []string `json:"tags"`
+	Siblings    []struct {
+		RFilename string `json:"rfilename"`
+	} `json:"siblings"`
+	Safetensors *struct {
+		Parameters map[string]int64 `json:"parameters"`
+		Total      int64            `json:"total"`
+	} `json:"safetensors"`
+}
+
+// dtypeBytes is the size in bytes of a parameter of each safetensors dtype.
+var dtypeBytes = map[string]int64{
+	"F64":     8,
+	"I64":     8,
+	"F32":     4,
+	"I32":     4,
+	"F16":     2,
+	"BF16":    2,
+	"I16":     2,
+	"F8_E4M3": 1,
+	"F8_E5M2": 1,
+	"I8":      1,
+	"U8":      1,
+	"BOOL":    1,
+}
+
+// safetensorsBytes returns the size of the safetensors weights of the model, or zero when the
+// Hub does not know it.
+func (m hubModel) safetensorsBytes() int64 {
+	if m.Safetensors == nil {
+		return 0
+	}
+	var size int64
+	for dtype, count := range m.Safetensors.Parameters {
+		bytes, ok := dtypeBytes[dtype]
+		if !ok {
+			bytes = 2
+		}
+		size += count * bytes
+	}
+	return size
+}
+
+// hubModelCache reads each model from the Hub at most once during a plan.
+type hubModelCache struct {
+	hub    *apiClient
+	models map[string]hubModel
+}
+
+func (c *hubModelCache) get(ctx context.Context, repository string, revision string) (hubModel, error) {
+	key := repository + "@" + revision
+	if model, ok := c.models[key]; ok {
+		return model, nil
+	}
+
+	model, err := c.hub.getModel(ctx, repository, revision)
+	if err != nil {
+		return hubModel{}, err
+	}
+	if c.models == nil {
+		c.models = make(map[string]hubModel)
+	}
+	c.models[key] = model
+	return model, nil
+}
+
+// isGated reports whether access to the model must be requested. The API returns false or
+// the gating mode.
+func (m hubModel) isGated() bool {
+	gated, ok := m.Gated.(bool)
+	return m.Gated != nil && (!ok || gated)
+}
+
+// license returns the license of the model as recorded in its tags.
+func (m hubModel) license() string {
+	for _, tag := range m.Tags {
+		if license, ok := strings.CutPrefix(tag, "license:"); ok {
+			return license
+		}
+	}
+	return ""
+}
+
+// safetensorsDtype returns the dtype holding most of the parameters of the model.
+func (m hubModel) safetensorsDtype() string {
+	if m.Safetensors == nil {
+		return ""
+	}
+	var dtype string
+	var count int64
+	for parametersDtype, parametersCount := range m.Safetensors.Parameters {
+		if parametersCount > count || (parametersCount == count && parametersDtype < dtype) {
+			dtype = parametersDtype
+			count = parametersCount
+		}
+	}
+	return dtype
+}
+
+func (c *apiClient) modelURL(repository string, revision string) string {
+	return strings.TrimSuffix(c.host, "/") + "/api/models/" + repository + "/revision/" + url.PathEscape(revision)
+}
+
+// getModel reads the Hub metadata of a model repository at the given revision. It must be
+// called on the client configured for the Hub host.
+func (c *apiClient) getModel(ctx context.Context, repository string, revision string) (hubModel, error) {
+	var model hubModel
+	err := c.doJSON(ctx, http.MethodGet, c.modelURL(repository, revision), nil, &model)
+	return model, err
+}
+
+// canDownloadModel reports whether the token can download the files of a gated model, which
+// requires accepting its licence on the Hub. Models that are not gated are always downloadable.
+func (c *apiClient) canDownloadModel(ctx context.Context, model hubModel, revision string) (bool, error) {
+	if !model.isGated() || len(model.Siblings) == 0 {
+		return true, nil
+	}
+
+	// a redirect to the storage backend means access was granted, there is no need to follow it
+	httpClient := *c.httpClient
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	client := *c
+	client.httpClient = &httpClient
+
+	fileURL := strings.TrimSuffix(c.host, "/") + "/" + model.ID + "/resolve/" + url.PathEscape(revision) + "/" + model.Siblings[0].RFilename
+	statusCode, _, err := client.send(ctx, http.MethodHead, fileURL, nil)
+	if err != nil {
+		return false, err
+	}
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return false, nil
+	}
+	return true, nil
+}
+
+// hubModelErrorDetail explains a failed Hub model request, telling missing repositories apart
+// from repositories the token cannot access.
+func hubModelErrorDetail(repository string, err error) string {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return "token has no access to " + repository + " (gated or private), request access on the Hub or use another token"
+		case http.StatusNotFound:
+			return "repository " + repository + " or its revision does not exist, or it is private and the token has no access to it"
+		}
+	}
+	return "could not read " + repository + " from the Hub: " + err.Error()
+}
+
+// frameworkForLibrary maps the library of a Hub model to an inference endpoints framework.
+func frameworkForLibrary(library string) string {
+	switch library {
+	case "keras", "tf-keras", "tensorflow":
+		return "tensorflow"
+	case "transformers", "sentence-transformers", "diffusers", "timm", "peft", "setfit", "adapter-transformers":
+		return "pytorch"
+	default:
+		return "custom"
+	}
+}
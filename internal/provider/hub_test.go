@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFrameworkForLibrary(t *testing.T) {
+	tests := map[string]string{
+		"transformers":          "pytorch",
+		"sentence-transformers": "pytorch",
+		"diffusers":             "pytorch",
+		"keras":                 "tensorflow",
+		"tensorflow":            "tensorflow",
+		"llama.cpp":             "custom",
+		"":                      "custom",
+	}
+
+	for library, want := range tests {
+		if got := frameworkForLibrary(library); got != want {
+			t.Errorf("frameworkForLibrary(%q) = %q, want %q", library, got, want)
+		}
+	}
+}
+
+func TestSafetensorsBytes(t *testing.T) {
+	tests := []struct {
+		name      string
+		model     string
+		wantBytes int64
+		wantDtype string
+	}{
+		{
+			name:  "no safetensors",
+			model: `{"id": "org/model"}`,
+		},
+		{
+			name:      "single dtype",
+			model:     `{"safetensors": {"parameters": {"BF16": 1000}, "total": 1000}}`,
+			wantBytes: 2000,
+			wantDtype: "BF16",
+		},
+		{
+			name:      "mixed dtypes",
+			model:     `{"safetensors": {"parameters": {"F32": 10, "I8": 100}, "total": 110}}`,
+			wantBytes: 140,
+			wantDtype: "I8",
+		},
+		{
+			name:      "unknown dtype counts as two bytes",
+			model:     `{"safetensors": {"parameters": {"F4": 10}, "total": 10}}`,
+			wantBytes: 20,
+			wantDtype: "F4",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var model hubModel
+			if err := json.Unmarshal([]byte(test.model), &model); err != nil {
+				t.Fatal(err)
+			}
+			if got := model.safetensorsBytes(); got != test.wantBytes {
+				t.Errorf("safetensorsBytes() = %d, want %d", got, test.wantBytes)
+			}
+			if got := model.safetensorsDtype(); got != test.wantDtype {
+				t.Errorf("safetensorsDtype() = %q, want %q", got, test.wantDtype)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ datasource.DataSource                     = &inferenceDataSource{}
+	_ datasource.DataSourceWithConfigure        = &inferenceDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &inferenceDataSource{}
+)
+
+func NewInferenceDataSource() datasource.DataSource {
+	return &inferenceDataSource{}
+}
+
+type inferenceDataSource struct {
+	namespaces *namespaceClients
+	guardrails guardrails
+}
+
+type inferenceDataSourceModel struct {
+	EndpointName types.String `tfsdk:"endpoint_name"`
+	Namespace    types.String `tfsdk:"namespace"`
+	URL          types.String `tfsdk:"url"`
+	Path         types.String `tfsdk:"path"`
+	Method       types.String `tfsdk:"method"`
+	Payload      types.String `tfsdk:"payload"`
+	StatusCode   types.Int64  `tfsdk:"status_code"`
+	LatencyMs    types.Int64  `tfsdk:"latency_ms"`
+	ResponseBody types.String `tfsdk:"response_body"`
+}
+
+func (d *inferenceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected data source configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	d.namespaces = data.namespaces
+	d.guardrails = data.guardrails
+}
+
+func (d *inferenceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_inference"
+}
+
+func (d *inferenceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"endpoint_name": schema.StringAttribute{
+				Optional: true,
+			},
+			"namespace": schema.StringAttribute{
+				Optional: true,
+			},
+			"url": schema.StringAttribute{
+				Optional: true,
+			},
+			"path": schema.StringAttribute{
+				Optional: true,
+			},
+			"method": schema.StringAttribute{
+				Optional: true,
+			},
+			"payload": schema.StringAttribute{
+				Required: true,
+			},
+			"status_code": schema.Int64Attribute{
+				Computed: true,
+			},
+			"latency_ms": schema.Int64Attribute{
+				Computed: true,
+			},
+			"response_body": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *inferenceDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("endpoint_name"),
+			path.MatchRoot("url"),
+		),
+	}
+}
+
+func (d *inferenceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config inferenceDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace := d.namespaces.defaultNamespace
+	if !config.Namespace.IsNull() {
+		namespace = config.Namespace.ValueString()
+	}
+	d.guardrails.checkNamespace(&resp.Diagnostics, path.Root("namespace"), namespace)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, api, err := d.namespaces.get(namespace)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"unable to create huggingface api client",
+			err.Error(),
+		)
+		return
+	}
+
+	endpointURL := config.URL.ValueString()
+	if !config.EndpointName.IsNull() {
+		endpoint, err := client.GetEndpoint(config.EndpointName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"error reading endpoint",
+				"could not read endpoint named "+config.EndpointName.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		d.guardrails.checkVendor(&resp.Diagnostics, path.Root("endpoint_name"), endpoint.Provider.Vendor)
+		d.guardrails.checkRegion(&resp.Diagnostics, path.Root("endpoint_name"), endpoint.Provider.Region)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		endpointURL = endpoint.Status.URL
+	} else if d.guardrails.enabled() {
+		// with allowlists, the url must be the one of an endpoint of the namespace, which is
+		// checked like an endpoint given by name
+		endpoint, found, err := endpointForURL(client, endpointURL)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"error listing endpoints",
+				"could not list the endpoints of namespace "+namespace+" to check the url against the allowlists of the provider: "+err.Error(),
+			)
+			return
+		}
+		if !found {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("url"),
+				"url not allowed",
+				"url "+endpointURL+" is not the url of an endpoint in namespace "+namespace+", which is required when the "+
+					"provider sets allowed_namespaces, allowed_regions or allowed_vendors",
+			)
+			return
+		}
+		d.guardrails.checkVendor(&resp.Diagnostics, path.Root("url"), endpoint.Provider.Vendor)
+		d.guardrails.checkRegion(&resp.Diagnostics, path.Root("url"), endpoint.Provider.Region)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if endpointURL == "" {
+		resp.Diagnostics.AddError(
+			"endpoint has no url",
+			"endpoint named "+config.EndpointName.ValueString()+" is not deployed yet",
+		)
+		return
+	}
+	if !config.Path.IsNull() {
+		endpointURL = strings.TrimSuffix(endpointURL, "/") + "/" + strings.TrimPrefix(config.Path.ValueString(), "/")
+	}
+
+	method := http.MethodPost
+	if !config.Method.IsNull() {
+		method = config.Method.ValueString()
+	}
+
+	start := time.Now()
+	statusCode, body, err := api.send(ctx, method, endpointURL, strings.NewReader(config.Payload.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error sending inference request",
+			"could not send inference request to "+endpointURL+": "+err.Error(),
+		)
+		return
+	}
+
+	config.StatusCode = types.Int64Value(int64(statusCode))
+	config.LatencyMs = types.Int64Value(time.Since(start).Milliseconds())
+	config.ResponseBody = types.StringValue(string(body))
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// endpointForURL returns the endpoint of the namespace of client that serves endpointURL.
+func endpointForURL(client *huggingface.Client, endpointURL string) (huggingface.EndpointDetails, bool, error) {
+	endpoints, err := client.ListEndpoints()
+	if err != nil {
+		return huggingface.EndpointDetails{}, false, err
+	}
+
+	for _, endpoint := range endpoints {
+		base := strings.TrimSuffix(endpoint.Status.URL, "/")
+		if base != "" && (endpointURL == base || strings.HasPrefix(endpointURL, base+"/")) {
+			return endpoint, true, nil
+		}
+	}
+	return huggingface.EndpointDetails{}, false, nil
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &modelDataSource{}
+	_ datasource.DataSourceWithConfigure = &modelDataSource{}
+)
+
+func NewModelDataSource() datasource.DataSource {
+	return &modelDataSource{}
+}
+
+type modelDataSource struct {
+	hub *apiClient
+}
+
+type modelDataSourceModel struct {
+	Repository            types.String `tfsdk:"repository"`
+	Revision              types.String `tfsdk:"revision"`
+	SHA                   types.String `tfsdk:"sha"`
+	PipelineTag           types.String `tfsdk:"pipeline_tag"`
+	Library               types.String `tfsdk:"library"`
+	License               types.String `tfsdk:"license"`
+	Gated                 types.Bool   `tfsdk:"gated"`
+	Private               types.Bool   `tfsdk:"private"`
+	Tags                  []string     `tfsdk:"tags"`
+	Files                 []string     `tfsdk:"files"`
+	SafetensorsParameters types.Int64  `tfsdk:"safetensors_parameters"`
+	SafetensorsDtype      types.String `tfsdk:"safetensors_dtype"`
+}
+
+func (d *modelDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected data source configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	d.hub = data.hub
+}
+
+func (d *modelDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model"
+}
+
+func (d *modelDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"repository": schema.StringAttribute{
+				Required: true,
+			},
+			"revision": schema.StringAttribute{
+				Optional: true,
+			},
+			"sha": schema.StringAttribute{
+				Computed: true,
+			},
+			"pipeline_tag": schema.StringAttribute{
+				Computed: true,
+			},
+			"library": schema.StringAttribute{
+				Computed: true,
+			},
+			"license": schema.StringAttribute{
+				Computed: true,
+			},
+			"gated": schema.BoolAttribute{
+				Computed: true,
+			},
+			"private": schema.BoolAttribute{
+				Computed: true,
+			},
+			"tags": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"files": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"safetensors_parameters": schema.Int64Attribute{
+				Computed: true,
+			},
+			"safetensors_dtype": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *modelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config modelDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	revision := defaultModelRevision
+	if !config.Revision.IsNull() {
+		revision = config.Revision.ValueString()
+	}
+
+	model, err := d.hub.getModel(ctx, config.Repository.ValueString(), revision)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error reading model",
+			hubModelErrorDetail(config.Repository.ValueString(), err),
+		)
+		return
+	}
+
+	config.SHA = types.StringValue(model.SHA)
+	config.PipelineTag = types.StringValue(model.PipelineTag)
+	config.Library = types.StringValue(model.LibraryName)
+	config.License = types.StringValue(model.license())
+	config.Gated = types.BoolValue(model.isGated())
+	config.Private = types.BoolValue(model.Private)
+	config.Tags = model.Tags
+	if config.Tags == nil {
+		config.Tags = []string{}
+	}
+	config.Files = make([]string, 0, len(model.Siblings))
+	for _, sibling := range model.Siblings {
+		config.Files = append(config.Files, sibling.RFilename)
+	}
+	config.SafetensorsParameters = types.Int64Null()
+	config.SafetensorsDtype = types.StringNull()
+	if model.Safetensors != nil {
+		config.SafetensorsParameters = types.Int64Value(model.Safetensors.Total)
+		if dtype := model.safetensorsDtype(); dtype != "" {
+			config.SafetensorsDtype = types.StringValue(dtype)
+		}
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
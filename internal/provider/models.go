@@ -20,29 +20,47 @@ type Compute struct {
 }
 
 type Scaling struct {
-	MaxReplica         int  `tfsdk:"max_replica"`
-	MinReplica         int  `tfsdk:"min_replica"`
-	ScaleToZeroTimeout *int `tfsdk:"scale_to_zero_timeout"`
+	MaxReplica         int      `tfsdk:"max_replica"`
+	Measure            *Measure `tfsdk:"measure"`
+	Metric             *string  `tfsdk:"metric"`
+	MinReplica         int      `tfsdk:"min_replica"`
+	ScaleToZeroTimeout *int     `tfsdk:"scale_to_zero_timeout"`
+}
+
+type Measure struct {
+	HardwareUsage   *float64 `tfsdk:"hardware_usage"`
+	PendingRequests *float64 `tfsdk:"pending_requests"`
 }
 
 type Model struct {
-	Framework  string       `tfsdk:"framework"`
+	Framework  types.String `tfsdk:"framework"`
 	Image      Image        `tfsdk:"image"`
 	Repository string       `tfsdk:"repository"`
 	Revision   types.String `tfsdk:"revision"`
 	Task       types.String `tfsdk:"task"`
+
+	ResolvedRevision types.String `tfsdk:"resolved_revision"`
+	TrackRevision    types.Bool   `tfsdk:"track_revision"`
 }
 
 type Image struct {
 	Huggingface *Huggingface `tfsdk:"huggingface"`
 	Custom      *Custom      `tfsdk:"custom"`
+	Tgi         *Tgi         `tfsdk:"tgi"`
+	Tei         *Tei         `tfsdk:"tei"`
+	Vllm        *Vllm        `tfsdk:"vllm"`
+	Llamacpp    *Llamacpp    `tfsdk:"llamacpp"`
 }
 
 type Custom struct {
+	Args        []string          `tfsdk:"args"`
+	Command     []string          `tfsdk:"command"`
 	Credentials *Credentials      `tfsdk:"credentials"`
 	Env         map[string]string `tfsdk:"env"`
 	HealthRoute *string           `tfsdk:"health_route"`
+	PinDigest   types.Bool        `tfsdk:"pin_digest"`
 	Port        *int              `tfsdk:"port"`
+	ResolvedURL types.String      `tfsdk:"resolved_url"`
 	URL         string            `tfsdk:"url"`
 }
 
@@ -55,6 +73,50 @@ type Huggingface struct {
 	Env map[string]string `tfsdk:"env"`
 }
 
+type Tgi struct {
+	DisableCustomKernels  *bool   `tfsdk:"disable_custom_kernels"`
+	HealthRoute           *string `tfsdk:"health_route"`
+	MaxBatchPrefillTokens *int    `tfsdk:"max_batch_prefill_tokens"`
+	MaxBatchTotalTokens   *int    `tfsdk:"max_batch_total_tokens"`
+	MaxInputTokens        *int    `tfsdk:"max_input_tokens"`
+	MaxTotalTokens        *int    `tfsdk:"max_total_tokens"`
+	Port                  *int    `tfsdk:"port"`
+	Quantize              *string `tfsdk:"quantize"`
+	URL                   string  `tfsdk:"url"`
+}
+
+type Tei struct {
+	HealthRoute           *string `tfsdk:"health_route"`
+	MaxBatchTokens        *int    `tfsdk:"max_batch_tokens"`
+	MaxConcurrentRequests *int    `tfsdk:"max_concurrent_requests"`
+	Pooling               *string `tfsdk:"pooling"`
+	Port                  *int    `tfsdk:"port"`
+	URL                   string  `tfsdk:"url"`
+}
+
+type Vllm struct {
+	HealthRoute         *string `tfsdk:"health_route"`
+	KvCacheDtype        *string `tfsdk:"kv_cache_dtype"`
+	MaxModelLen         *int    `tfsdk:"max_model_len"`
+	MaxNumBatchedTokens *int    `tfsdk:"max_num_batched_tokens"`
+	MaxNumSeqs          *int    `tfsdk:"max_num_seqs"`
+	Port                *int    `tfsdk:"port"`
+	TensorParallelSize  *int    `tfsdk:"tensor_parallel_size"`
+	URL                 string  `tfsdk:"url"`
+}
+
+type Llamacpp struct {
+	CtxSize     *int    `tfsdk:"ctx_size"`
+	Embeddings  *bool   `tfsdk:"embeddings"`
+	GGUFFile    string  `tfsdk:"gguf_file"`
+	HealthRoute *string `tfsdk:"health_route"`
+	NParallel   *int    `tfsdk:"n_parallel"`
+	Pooling     *string `tfsdk:"pooling"`
+	Port        *int    `tfsdk:"port"`
+	ThreadsHttp *int    `tfsdk:"threads_http"`
+	URL         string  `tfsdk:"url"`
+}
+
 type Cloud struct {
 	Region string `tfsdk:"region"`
 	Vendor string `tfsdk:"vendor"`
@@ -82,3 +144,23 @@ type User struct {
 type Private struct {
 	ServiceName string `tfsdk:"service_name"`
 }
+
+type EndpointDefaults struct {
+	Cloud              *Cloud            `tfsdk:"cloud"`
+	Env                map[string]string `tfsdk:"env"`
+	ScaleToZeroTimeout *int              `tfsdk:"scale_to_zero_timeout"`
+	Type               *string           `tfsdk:"type"`
+}
+
+type PrivateEndpoint struct {
+	AccountId string     `tfsdk:"account_id"`
+	Shared    types.Bool `tfsdk:"shared"`
+}
+
+type HealthCheck struct {
+	Body           *string `tfsdk:"body"`
+	ExpectedStatus int     `tfsdk:"expected_status"`
+	Method         string  `tfsdk:"method"`
+	Path           string  `tfsdk:"path"`
+	Retries        int     `tfsdk:"retries"`
+}
@@ -3,11 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/issamemari/huggingface-endpoints-client-go"
@@ -49,16 +53,97 @@ func (p *huggingfaceProvider) Schema(_ context.Context, _ provider.SchemaRequest
 				Optional:  true,
 				Sensitive: true,
 			},
+			"failure_log_lines": schema.Int64Attribute{
+				Optional: true,
+			},
+			"hub_host": schema.StringAttribute{
+				Optional: true,
+			},
+			"strict_fit_check": schema.BoolAttribute{
+				Optional: true,
+			},
+			"default_tags": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"allowed_namespaces": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"allowed_regions": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"allowed_vendors": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"max_hourly_cost_per_endpoint": schema.Float64Attribute{
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_hourly_cost_total": schema.Float64Attribute{
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"endpoint_defaults": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"cloud": schema.SingleNestedAttribute{
+						Optional: true,
+						Attributes: map[string]schema.Attribute{
+							"region": schema.StringAttribute{
+								Required: true,
+							},
+							"vendor": schema.StringAttribute{
+								Required: true,
+							},
+						},
+					},
+					"env": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"scale_to_zero_timeout": schema.Int64Attribute{
+						Optional: true,
+					},
+					"type": schema.StringAttribute{
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(endpointTypePublic, endpointTypeProtected, endpointTypePrivate, endpointTypeAuthenticated),
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
 type huggingfaceProviderModel struct {
-	Host      types.String `tfsdk:"host"`
-	Namespace types.String `tfsdk:"namespace"`
-	Token     types.String `tfsdk:"token"`
+	Host            types.String `tfsdk:"host"`
+	Namespace       types.String `tfsdk:"namespace"`
+	Token           types.String `tfsdk:"token"`
+	FailureLogLines types.Int64  `tfsdk:"failure_log_lines"`
+	HubHost         types.String `tfsdk:"hub_host"`
+	StrictFitCheck  types.Bool   `tfsdk:"strict_fit_check"`
+	DefaultTags     types.Set    `tfsdk:"default_tags"`
+
+	AllowedNamespaces types.Set `tfsdk:"allowed_namespaces"`
+	AllowedRegions    types.Set `tfsdk:"allowed_regions"`
+	AllowedVendors    types.Set `tfsdk:"allowed_vendors"`
+
+	MaxHourlyCostPerEndpoint types.Float64 `tfsdk:"max_hourly_cost_per_endpoint"`
+	MaxHourlyCostTotal       types.Float64 `tfsdk:"max_hourly_cost_total"`
+
+	EndpointDefaults *EndpointDefaults `tfsdk:"endpoint_defaults"`
 }
 
+const defaultFailureLogLines = 50
+
 func ValidateConfiguration(config huggingfaceProviderModel, resp *provider.ConfigureResponse) error {
 	if config.Host.IsUnknown() || config.Host.IsNull() || config.Host.ValueString() == "" {
 		resp.Diagnostics.AddError("host", "huggingface api host unknown or empty")
@@ -69,6 +154,33 @@ func ValidateConfiguration(config huggingfaceProviderModel, resp *provider.Confi
 	if config.Token.IsUnknown() || config.Token.IsNull() || config.Token.ValueString() == "" {
 		resp.Diagnostics.AddError("token", "huggingface api token unknown or empty")
 	}
+	if config.HubHost.IsUnknown() {
+		resp.Diagnostics.AddError("hub_host", "huggingface hub host unknown")
+	}
+	if config.StrictFitCheck.IsUnknown() {
+		resp.Diagnostics.AddError("strict_fit_check", "strict fit check setting unknown")
+	}
+	if config.DefaultTags.IsUnknown() {
+		resp.Diagnostics.AddError("default_tags", "default tags unknown")
+	}
+	if config.AllowedNamespaces.IsUnknown() {
+		resp.Diagnostics.AddError("allowed_namespaces", "allowed namespaces unknown")
+	}
+	if config.AllowedRegions.IsUnknown() {
+		resp.Diagnostics.AddError("allowed_regions", "allowed regions unknown")
+	}
+	if config.AllowedVendors.IsUnknown() {
+		resp.Diagnostics.AddError("allowed_vendors", "allowed vendors unknown")
+	}
+	if config.MaxHourlyCostPerEndpoint.IsUnknown() {
+		resp.Diagnostics.AddError("max_hourly_cost_per_endpoint", "maximum hourly cost per endpoint unknown")
+	}
+	if config.MaxHourlyCostTotal.IsUnknown() {
+		resp.Diagnostics.AddError("max_hourly_cost_total", "maximum total hourly cost unknown")
+	}
+	if config.FailureLogLines.IsUnknown() || config.FailureLogLines.ValueInt64() < 0 {
+		resp.Diagnostics.AddError("failure_log_lines", "failure log line count unknown or negative")
+	}
 	if resp.Diagnostics.HasError() {
 		return fmt.Errorf("invalid configuration")
 	}
@@ -108,14 +220,84 @@ func (p *huggingfaceProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	failureLogLines := defaultFailureLogLines
+	if !config.FailureLogLines.IsNull() {
+		failureLogLines = int(config.FailureLogLines.ValueInt64())
+	}
+
+	hubHost := defaultHubHost
+	if !config.HubHost.IsNull() && config.HubHost.ValueString() != "" {
+		hubHost = config.HubHost.ValueString()
+	}
+
+	var defaultTags []string
+	resp.Diagnostics.Append(config.DefaultTags.ElementsAs(ctx, &defaultTags, false)...)
+
+	var allowed guardrails
+	resp.Diagnostics.Append(config.AllowedNamespaces.ElementsAs(ctx, &allowed.allowedNamespaces, false)...)
+	resp.Diagnostics.Append(config.AllowedRegions.ElementsAs(ctx, &allowed.allowedRegions, false)...)
+	resp.Diagnostics.Append(config.AllowedVendors.ElementsAs(ctx, &allowed.allowedVendors, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var endpointDefaults EndpointDefaults
+	if config.EndpointDefaults != nil {
+		endpointDefaults = *config.EndpointDefaults
+	}
+
+	api := &apiClient{
+		host:       host,
+		namespace:  namespace,
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+
+	data := &providerData{
+		httpClient: http.DefaultClient,
+		hub: &apiClient{
+			host:       hubHost,
+			token:      token,
+			httpClient: http.DefaultClient,
+		},
+		namespaces: &namespaceClients{
+			host:             host,
+			token:            token,
+			defaultNamespace: namespace,
+			httpClient:       http.DefaultClient,
+			clients:          map[string]*huggingface.Client{namespace: client},
+			apis:             map[string]*apiClient{namespace: api},
+		},
+		failureLogLines: failureLogLines,
+		strictFitCheck:  config.StrictFitCheck.ValueBool(),
+		defaultTags:     defaultTags,
+		guardrails:      allowed,
+		catalogues: &computeCatalogueCache{
+			api:        api,
+			catalogues: map[string][]computeInstance{},
+		},
+		budget: &budget{
+			maxPerEndpoint: config.MaxHourlyCostPerEndpoint.ValueFloat64Pointer(),
+			maxTotal:       config.MaxHourlyCostTotal.ValueFloat64Pointer(),
+			costs:          map[string]endpointCost{},
+			replaced:       map[string][]string{},
+		},
+
+		endpointDefaults: endpointDefaults,
+	}
+
+	resp.DataSourceData = data
+	resp.ResourceData = data
 
 	tflog.Info(ctx, "huggingface provider configured", map[string]any{"success": true})
 }
 
 func (p *huggingfaceProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewInferenceDataSource,
+		NewEndpointHealthDataSource,
+		NewModelDataSource,
+	}
 }
 
 func (p *huggingfaceProvider) Resources(_ context.Context) []func() resource.Resource {
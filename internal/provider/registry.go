@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	dockerHubRegistry = "registry-1.docker.io"
+	digestHeader      = "Docker-Content-Digest"
+)
+
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+type imageReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// parseImageReference splits a container image reference such as ghcr.io/org/image:tag into
+// its parts, applying the Docker Hub defaults for registry and tag.
+func parseImageReference(image string) imageReference {
+	var ref imageReference
+
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 && !strings.Contains(name[i:], "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	}
+
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		ref.Registry = parts[0]
+		ref.Repository = parts[1]
+	} else {
+		ref.Registry = dockerHubRegistry
+		ref.Repository = name
+	}
+	if ref.Registry == "docker.io" || ref.Registry == "index.docker.io" {
+		ref.Registry = dockerHubRegistry
+	}
+	if ref.Registry == dockerHubRegistry && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+	return ref
+}
+
+// resolveImageDigest returns the image reference pinned to the digest its tag currently points
+// to. References that already carry a digest are returned unchanged.
+func resolveImageDigest(ctx context.Context, httpClient *http.Client, image string, credentials *Credentials) (string, error) {
+	ref := parseImageReference(image)
+	if ref.Digest != "" {
+		return image, nil
+	}
+
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", ref.Registry, ref.Repository, ref.Tag)
+	resp, err := headManifest(ctx, httpClient, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		authorization, err := registryAuthorization(ctx, httpClient, resp.Header.Get("WWW-Authenticate"), credentials)
+		if err != nil {
+			return "", fmt.Errorf("could not authenticate with registry %s: %w", ref.Registry, err)
+		}
+		resp, err = headManifest(ctx, httpClient, manifestURL, authorization)
+		if err != nil {
+			return "", err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not read manifest of %s: unexpected status code %d", image, resp.StatusCode)
+	}
+
+	digest := resp.Header.Get(digestHeader)
+	if digest == "" {
+		return "", fmt.Errorf("registry %s did not return a digest for %s", ref.Registry, image)
+	}
+
+	name := strings.TrimSuffix(image, ":"+ref.Tag)
+	return name + "@" + digest, nil
+}
+
+func headManifest(ctx context.Context, httpClient *http.Client, manifestURL string, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// registryAuthorization answers the challenge returned by a registry with the value of an
+// Authorization header. Basic challenges need the registry credentials; Bearer challenges use
+// them when they are set.
+func registryAuthorization(ctx context.Context, httpClient *http.Client, challenge string, credentials *Credentials) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	switch {
+	case strings.EqualFold(scheme, "Bearer"):
+		token, err := registryToken(ctx, httpClient, challenge, params, credentials)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	case strings.EqualFold(scheme, "Basic"):
+		if credentials == nil {
+			return "", fmt.Errorf("the registry requires basic authentication but the image has no credentials")
+		}
+		userinfo := credentials.Username + ":" + credentials.Password
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(userinfo)), nil
+	default:
+		return "", fmt.Errorf("unsupported authentication scheme %q in challenge %q", scheme, challenge)
+	}
+}
+
+// registryToken obtains a bearer token from the realm of a Bearer challenge, using the registry
+// credentials when they are set.
+func registryToken(ctx context.Context, httpClient *http.Client, challenge string, params string, credentials *Credentials) (string, error) {
+	values := url.Values{}
+	var realm string
+	for _, param := range strings.Split(params, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		if key == "realm" {
+			realm = value
+		} else {
+			values.Set(key, value)
+		}
+	}
+	if realm == "" {
+		return "", fmt.Errorf("registry authentication challenge %q has no realm", challenge)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+values.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	if credentials != nil {
+		req.SetBasicAuth(credentials.Username, credentials.Password)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, realm)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		image string
+		want  imageReference
+	}{
+		{
+			image: "ubuntu",
+			want:  imageReference{Registry: dockerHubRegistry, Repository: "library/ubuntu", Tag: "latest"},
+		},
+		{
+			image: "ubuntu:22.04",
+			want:  imageReference{Registry: dockerHubRegistry, Repository: "library/ubuntu", Tag: "22.04"},
+		},
+		{
+			image: "huggingface/text-generation-inference:1.4",
+			want:  imageReference{Registry: dockerHubRegistry, Repository: "huggingface/text-generation-inference", Tag: "1.4"},
+		},
+		{
+			image: "docker.io/nginx",
+			want:  imageReference{Registry: dockerHubRegistry, Repository: "library/nginx", Tag: "latest"},
+		},
+		{
+			image: "index.docker.io/org/image:tag",
+			want:  imageReference{Registry: dockerHubRegistry, Repository: "org/image", Tag: "tag"},
+		},
+		{
+			image: "ghcr.io/huggingface/text-generation-inference:2.0",
+			want:  imageReference{Registry: "ghcr.io", Repository: "huggingface/text-generation-inference", Tag: "2.0"},
+		},
+		{
+			image: "localhost/image",
+			want:  imageReference{Registry: "localhost", Repository: "image", Tag: "latest"},
+		},
+		{
+			image: "registry.example.com:5000/team/image",
+			want:  imageReference{Registry: "registry.example.com:5000", Repository: "team/image", Tag: "latest"},
+		},
+		{
+			image: "registry.example.com:5000/team/image:v1",
+			want:  imageReference{Registry: "registry.example.com:5000", Repository: "team/image", Tag: "v1"},
+		},
+		{
+			image: "ghcr.io/org/image@sha256:abc",
+			want:  imageReference{Registry: "ghcr.io", Repository: "org/image", Digest: "sha256:abc"},
+		},
+		{
+			image: "ghcr.io/org/image:v1@sha256:abc",
+			want:  imageReference{Registry: "ghcr.io", Repository: "org/image", Tag: "v1", Digest: "sha256:abc"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			got := parseImageReference(test.image)
+			if got != test.want {
+				t.Errorf("parseImageReference(%q) = %+v, want %+v", test.image, got, test.want)
+			}
+		})
+	}
+}
+
+func TestResolveImageDigest(t *testing.T) {
+	const digest = "sha256:0123456789abcdef"
+
+	tests := []struct {
+		name        string
+		challenge   string
+		credentials *Credentials
+		want        string
+		wantErr     string
+	}{
+		{
+			name: "anonymous",
+			want: digest,
+		},
+		{
+			name:        "basic",
+			challenge:   `Basic realm="registry"`,
+			credentials: &Credentials{Username: "user", Password: "secret"},
+			want:        digest,
+		},
+		{
+			name:      "basic without credentials",
+			challenge: `Basic realm="registry"`,
+			wantErr:   "requires basic authentication",
+		},
+		{
+			name:        "unsupported scheme",
+			challenge:   `Negotiate`,
+			credentials: &Credentials{Username: "user", Password: "secret"},
+			wantErr:     `unsupported authentication scheme "Negotiate"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v2/team/image/manifests/v1" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				if test.challenge != "" {
+					username, password, ok := r.BasicAuth()
+					if !ok || username != "user" || password != "secret" {
+						w.Header().Set("WWW-Authenticate", test.challenge)
+						w.WriteHeader(http.StatusUnauthorized)
+						return
+					}
+				}
+				w.Header().Set(digestHeader, digest)
+			}))
+			defer server.Close()
+
+			registry := strings.TrimPrefix(server.URL, "https://")
+			got, err := resolveImageDigest(context.Background(), server.Client(), registry+"/team/image:v1", test.credentials)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("resolveImageDigest() error = %v, want it to contain %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveImageDigest() error = %v", err)
+			}
+			if want := registry + "/team/image@" + test.want; got != want {
+				t.Errorf("resolveImageDigest() = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -2,17 +2,45 @@ package provider
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/issamemari/huggingface-endpoints-client-go"
 )
 
 var (
-	_ resource.Resource              = &endpointResource{}
-	_ resource.ResourceWithConfigure = &endpointResource{}
+	_ resource.Resource                     = &endpointResource{}
+	_ resource.ResourceWithConfigure        = &endpointResource{}
+	_ resource.ResourceWithConfigValidators = &endpointResource{}
+	_ resource.ResourceWithModifyPlan       = &endpointResource{}
+	_ resource.ResourceWithValidateConfig   = &endpointResource{}
 )
 
 func NewEndpointResource() resource.Resource {
@@ -20,38 +48,111 @@ func NewEndpointResource() resource.Resource {
 }
 
 type endpointResource struct {
-	client *huggingface.Client
+	httpClient      *http.Client
+	hub             *apiClient
+	namespaces      *namespaceClients
+	failureLogLines int
+	strictFitCheck  bool
+	defaultTags     []string
+	guardrails      guardrails
+	budget          *budget
+	catalogues      *computeCatalogueCache
+
+	endpointDefaults EndpointDefaults
 }
 
 type endpointResourceModel struct {
-	AccountId types.String `tfsdk:"account_id"`
-	Compute   Compute      `tfsdk:"compute"`
-	Model     Model        `tfsdk:"model"`
-	Name      types.String `tfsdk:"name"`
-	Cloud     Cloud        `tfsdk:"cloud"`
-	Type      types.String `tfsdk:"type"`
+	AccountId          types.String     `tfsdk:"account_id"`
+	Compute            Compute          `tfsdk:"compute"`
+	Model              Model            `tfsdk:"model"`
+	Name               types.String     `tfsdk:"name"`
+	NamePrefix         types.String     `tfsdk:"name_prefix"`
+	Namespace          types.String     `tfsdk:"namespace"`
+	Cloud              Cloud            `tfsdk:"cloud"`
+	Type               types.String     `tfsdk:"type"`
+	Private            *PrivateEndpoint `tfsdk:"private"`
+	PrivateServiceName types.String     `tfsdk:"private_service_name"`
+	Status             *Status          `tfsdk:"status"`
+	Tags               []string         `tfsdk:"tags"`
+	TagsAll            []string         `tfsdk:"tags_all"`
+	OnFailure          types.String     `tfsdk:"on_failure"`
+	RollbackOnFailure  types.Bool       `tfsdk:"rollback_on_failure"`
+	HealthCheck        *HealthCheck     `tfsdk:"health_check"`
+	Timeouts           timeouts.Value   `tfsdk:"timeouts"`
 }
 
+const (
+	maxEndpointNameLength    = 32
+	endpointNameSuffixLength = 8
+)
+
+var (
+	endpointNameRegexp       = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+	endpointNamePrefixRegexp = regexp.MustCompile(`^[a-z][-a-z0-9]*$`)
+)
+
+const (
+	endpointTypePublic        = "public"
+	endpointTypeProtected     = "protected"
+	endpointTypePrivate       = "private"
+	endpointTypeAuthenticated = "authenticated"
+)
+
+const (
+	scalingMetricHardwareUsage   = "hardware_usage"
+	scalingMetricPendingRequests = "pending_requests"
+)
+
+const (
+	onFailureTaint  = "taint"
+	onFailureDelete = "delete"
+	onFailureKeep   = "keep"
+)
+
+const (
+	defaultCreateTimeout = 30 * time.Minute
+	defaultUpdateTimeout = 30 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+	pollInterval         = 10 * time.Second
+)
+
+const (
+	endpointStateFailed       = "failed"
+	endpointStatePaused       = "paused"
+	endpointStateRunning      = "running"
+	endpointStateScaledToZero = "scaledToZero"
+	endpointStateUpdateFailed = "updateFailed"
+)
+
 func (r *endpointResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*huggingface.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"unexpected data source configure type",
-			fmt.Sprintf("expected *huggingface.Client, got: %T.", req.ProviderData),
+			"unexpected resource configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
-	r.client = client
+	r.httpClient = data.httpClient
+	r.hub = data.hub
+	r.namespaces = data.namespaces
+	r.failureLogLines = data.failureLogLines
+	r.strictFitCheck = data.strictFitCheck
+	r.defaultTags = data.defaultTags
+	r.guardrails = data.guardrails
+	r.budget = data.budget
+	r.catalogues = data.catalogues
+	r.endpointDefaults = data.endpointDefaults
 }
 
 func (r *endpointResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_endpoint"
 }
 
-func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *endpointResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"account_id": schema.StringAttribute{
@@ -75,11 +176,35 @@ func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 							"max_replica": schema.Int64Attribute{
 								Required: true,
 							},
+							"measure": schema.SingleNestedAttribute{
+								Optional: true,
+								Attributes: map[string]schema.Attribute{
+									"hardware_usage": schema.Float64Attribute{
+										Optional: true,
+										Validators: []validator.Float64{
+											float64validator.Between(1, 100),
+										},
+									},
+									"pending_requests": schema.Float64Attribute{
+										Optional: true,
+										Validators: []validator.Float64{
+											float64validator.AtLeast(0.1),
+										},
+									},
+								},
+							},
+							"metric": schema.StringAttribute{
+								Optional: true,
+								Validators: []validator.String{
+									stringvalidator.OneOf(scalingMetricHardwareUsage, scalingMetricPendingRequests),
+								},
+							},
 							"min_replica": schema.Int64Attribute{
 								Required: true,
 							},
 							"scale_to_zero_timeout": schema.Int64Attribute{
 								Optional: true,
+								Computed: true,
 							},
 						},
 					},
@@ -89,7 +214,8 @@ func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Required: true,
 				Attributes: map[string]schema.Attribute{
 					"framework": schema.StringAttribute{
-						Required: true,
+						Optional: true,
+						Computed: true,
 					},
 					"image": schema.SingleNestedAttribute{
 						Required: true,
@@ -99,6 +225,7 @@ func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 								Attributes: map[string]schema.Attribute{
 									"env": schema.MapAttribute{
 										Optional:    true,
+										Computed:    true,
 										ElementType: types.StringType,
 									},
 								},
@@ -106,6 +233,14 @@ func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 							"custom": schema.SingleNestedAttribute{
 								Optional: true,
 								Attributes: map[string]schema.Attribute{
+									"args": schema.ListAttribute{
+										Optional:    true,
+										ElementType: types.StringType,
+									},
+									"command": schema.ListAttribute{
+										Optional:    true,
+										ElementType: types.StringType,
+									},
 									"credentials": schema.SingleNestedAttribute{
 										Optional: true,
 										Attributes: map[string]schema.Attribute{
@@ -119,14 +254,167 @@ func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 									},
 									"env": schema.MapAttribute{
 										Optional:    true,
+										Computed:    true,
 										ElementType: types.StringType,
 									},
 									"health_route": schema.StringAttribute{
 										Optional: true,
 									},
+									"pin_digest": schema.BoolAttribute{
+										Optional: true,
+										Computed: true,
+										Default:  booldefault.StaticBool(false),
+									},
+									"port": schema.Int64Attribute{
+										Optional: true,
+									},
+									"resolved_url": schema.StringAttribute{
+										Computed: true,
+									},
+									"url": schema.StringAttribute{
+										Required: true,
+									},
+								},
+							},
+							"tgi": schema.SingleNestedAttribute{
+								Optional: true,
+								Attributes: map[string]schema.Attribute{
+									"disable_custom_kernels": schema.BoolAttribute{
+										Optional: true,
+									},
+									"health_route": schema.StringAttribute{
+										Optional: true,
+									},
+									"max_batch_prefill_tokens": schema.Int64Attribute{
+										Optional:   true,
+										Validators: []validator.Int64{int64validator.AtLeast(1)},
+									},
+									"max_batch_total_tokens": schema.Int64Attribute{
+										Optional:   true,
+										Validators: []validator.Int64{int64validator.AtLeast(1)},
+									},
+									"max_input_tokens": schema.Int64Attribute{
+										Optional:   true,
+										Validators: []validator.Int64{int64validator.AtLeast(1)},
+									},
+									"max_total_tokens": schema.Int64Attribute{
+										Optional:   true,
+										Validators: []validator.Int64{int64validator.AtLeast(1)},
+									},
+									"port": schema.Int64Attribute{
+										Optional: true,
+									},
+									"quantize": schema.StringAttribute{
+										Optional: true,
+										Validators: []validator.String{
+											stringvalidator.OneOf("awq", "bitsandbytes", "bitsandbytes-nf4", "bitsandbytes-fp4", "eetq", "exl2", "fp8", "gptq", "marlin"),
+										},
+									},
+									"url": schema.StringAttribute{
+										Required: true,
+									},
+								},
+							},
+							"tei": schema.SingleNestedAttribute{
+								Optional: true,
+								Attributes: map[string]schema.Attribute{
+									"health_route": schema.StringAttribute{
+										Optional: true,
+									},
+									"max_batch_tokens": schema.Int64Attribute{
+										Optional:   true,
+										Validators: []validator.Int64{int64validator.AtLeast(1)},
+									},
+									"max_concurrent_requests": schema.Int64Attribute{
+										Optional:   true,
+										Validators: []validator.Int64{int64validator.AtLeast(1)},
+									},
+									"pooling": schema.StringAttribute{
+										Optional: true,
+										Validators: []validator.String{
+											stringvalidator.OneOf("cls", "mean", "splade", "last-token"),
+										},
+									},
+									"port": schema.Int64Attribute{
+										Optional: true,
+									},
+									"url": schema.StringAttribute{
+										Required: true,
+									},
+								},
+							},
+							"vllm": schema.SingleNestedAttribute{
+								Optional: true,
+								Attributes: map[string]schema.Attribute{
+									"health_route": schema.StringAttribute{
+										Optional: true,
+									},
+									"kv_cache_dtype": schema.StringAttribute{
+										Optional: true,
+										Validators: []validator.String{
+											stringvalidator.OneOf("auto", "fp8", "fp8_e4m3", "fp8_e5m2"),
+										},
+									},
+									"max_model_len": schema.Int64Attribute{
+										Optional:   true,
+										Validators: []validator.Int64{int64validator.AtLeast(1)},
+									},
+									"max_num_batched_tokens": schema.Int64Attribute{
+										Optional:   true,
+										Validators: []validator.Int64{int64validator.AtLeast(1)},
+									},
+									"max_num_seqs": schema.Int64Attribute{
+										Optional:   true,
+										Validators: []validator.Int64{int64validator.AtLeast(1)},
+									},
+									"port": schema.Int64Attribute{
+										Optional: true,
+									},
+									"tensor_parallel_size": schema.Int64Attribute{
+										Optional:   true,
+										Validators: []validator.Int64{int64validator.AtLeast(1)},
+									},
+									"url": schema.StringAttribute{
+										Required: true,
+									},
+								},
+							},
+							"llamacpp": schema.SingleNestedAttribute{
+								Optional: true,
+								Attributes: map[string]schema.Attribute{
+									"ctx_size": schema.Int64Attribute{
+										Optional:   true,
+										Validators: []validator.Int64{int64validator.AtLeast(1)},
+									},
+									"embeddings": schema.BoolAttribute{
+										Optional: true,
+									},
+									"gguf_file": schema.StringAttribute{
+										Required: true,
+										Validators: []validator.String{
+											stringvalidator.RegexMatches(regexp.MustCompile(`\.gguf$`), "must be the path of a GGUF file"),
+										},
+									},
+									"health_route": schema.StringAttribute{
+										Optional: true,
+									},
+									"n_parallel": schema.Int64Attribute{
+										Optional:   true,
+										Validators: []validator.Int64{int64validator.AtLeast(1)},
+									},
+									"pooling": schema.StringAttribute{
+										Optional: true,
+										Validators: []validator.String{
+											stringvalidator.OneOf("none", "mean", "cls", "last"),
+										},
+									},
 									"port": schema.Int64Attribute{
 										Optional: true,
 									},
+									"threads_http": schema.Int64Attribute{
+										Optional:   true,
+										Validators: []validator.Int64{int64validator.AtLeast(1)},
+									},
 									"url": schema.StringAttribute{
 										Required: true,
 									},
@@ -140,17 +428,57 @@ func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 					"revision": schema.StringAttribute{
 						Computed: true,
 						Optional: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"resolved_revision": schema.StringAttribute{
+						Computed: true,
+					},
+					"track_revision": schema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"task": schema.StringAttribute{
-						Required: true,
+						Optional: true,
+						Computed: true,
 					},
 				},
 			},
 			"name": schema.StringAttribute{
-				Required: true,
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(maxEndpointNameLength),
+					stringvalidator.RegexMatches(endpointNameRegexp, "must start with a lowercase letter, contain only lowercase letters, digits and hyphens, and not end with a hyphen"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(maxEndpointNameLength - endpointNameSuffixLength),
+					stringvalidator.RegexMatches(endpointNamePrefixRegexp, "must start with a lowercase letter and contain only lowercase letters, digits and hyphens"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"cloud": schema.SingleNestedAttribute{
-				Required: true,
+				Optional: true,
+				Computed: true,
 				Attributes: map[string]schema.Attribute{
 					"region": schema.StringAttribute{
 						Required: true,
@@ -159,176 +487,1312 @@ func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 						Required: true,
 					},
 				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+					objectplanmodifier.RequiresReplace(),
+				},
 			},
 			"type": schema.StringAttribute{
-				Required: true,
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(endpointTypePublic, endpointTypeProtected, endpointTypePrivate, endpointTypeAuthenticated),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"account_id": schema.StringAttribute{
+						Required: true,
+					},
+					"shared": schema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"private_service_name": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tags": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"tags_all": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"status": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"created_at": schema.StringAttribute{
+						Computed: true,
+					},
+					"created_by": schema.SingleNestedAttribute{
+						Computed:   true,
+						Attributes: userAttributes(),
+					},
+					"error_message": schema.StringAttribute{
+						Computed: true,
+					},
+					"message": schema.StringAttribute{
+						Computed: true,
+					},
+					"private": schema.SingleNestedAttribute{
+						Computed: true,
+						Attributes: map[string]schema.Attribute{
+							"service_name": schema.StringAttribute{
+								Computed: true,
+							},
+						},
+					},
+					"ready_replica": schema.Int64Attribute{
+						Computed: true,
+					},
+					"state": schema.StringAttribute{
+						Computed: true,
+					},
+					"target_replica": schema.Int64Attribute{
+						Computed: true,
+					},
+					"updated_at": schema.StringAttribute{
+						Computed: true,
+					},
+					"updated_by": schema.SingleNestedAttribute{
+						Computed:   true,
+						Attributes: userAttributes(),
+					},
+					"url": schema.StringAttribute{
+						Computed: true,
+					},
+				},
+			},
+			"on_failure": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(onFailureTaint),
+				Validators: []validator.String{
+					stringvalidator.OneOf(onFailureTaint, onFailureDelete, onFailureKeep),
+				},
+			},
+			"rollback_on_failure": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
+			"health_check": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"body": schema.StringAttribute{
+						Optional: true,
+					},
+					"expected_status": schema.Int64Attribute{
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(http.StatusOK),
+					},
+					"method": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString(http.MethodGet),
+					},
+					"path": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString("/"),
+					},
+					"retries": schema.Int64Attribute{
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(5),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func userAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+		},
+		"name": schema.StringAttribute{
+			Computed: true,
 		},
 	}
 }
 
-func clientEndpointToProviderEndpoint(endpoint huggingface.EndpointDetails) endpointResourceModel {
+func (r *endpointResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	image := path.MatchRoot("model").AtName("image")
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("name"),
+			path.MatchRoot("name_prefix"),
+		),
+		resourcevalidator.ExactlyOneOf(
+			image.AtName("huggingface"),
+			image.AtName("custom"),
+			image.AtName("tgi"),
+			image.AtName("tei"),
+			image.AtName("vllm"),
+			image.AtName("llamacpp"),
+		),
+	}
+}
+
+func (r *endpointResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	tgi := path.Root("model").AtName("image").AtName("tgi")
+
+	var maxInputTokens, maxTotalTokens types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, tgi.AtName("max_input_tokens"), &maxInputTokens)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, tgi.AtName("max_total_tokens"), &maxTotalTokens)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !maxInputTokens.IsNull() && !maxInputTokens.IsUnknown() && !maxTotalTokens.IsNull() && !maxTotalTokens.IsUnknown() &&
+		maxInputTokens.ValueInt64() >= maxTotalTokens.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			tgi.AtName("max_input_tokens"),
+			"invalid tgi configuration",
+			fmt.Sprintf("max_input_tokens (%d) must be lower than max_total_tokens (%d)", maxInputTokens.ValueInt64(), maxTotalTokens.ValueInt64()),
+		)
+	}
+
+	var endpointType types.String
+	var private types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &endpointType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("private"), &private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validatePrivateConfiguration(endpointType, private, &resp.Diagnostics)
+
+	scaling := path.Root("compute").AtName("scaling")
+
+	var accelerator, metric types.String
+	var hardwareUsage, pendingRequests types.Float64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("compute").AtName("accelerator"), &accelerator)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, scaling.AtName("metric"), &metric)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, scaling.AtName("measure").AtName("hardware_usage"), &hardwareUsage)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, scaling.AtName("measure").AtName("pending_requests"), &pendingRequests)...)
+	if resp.Diagnostics.HasError() || metric.IsNull() || metric.IsUnknown() {
+		return
+	}
+
+	// the autoscaler needs a threshold for the metric it scales on
+	threshold := hardwareUsage
+	if metric.ValueString() == scalingMetricPendingRequests {
+		threshold = pendingRequests
+	}
+	if threshold.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			scaling.AtName("measure").AtName(metric.ValueString()),
+			"invalid scaling configuration",
+			fmt.Sprintf("measure.%s must be set when scaling on the %s metric", metric.ValueString(), metric.ValueString()),
+		)
+	}
+
+	// scaling on pending requests is only available for gpu endpoints
+	if metric.ValueString() == scalingMetricPendingRequests && !accelerator.IsUnknown() && accelerator.ValueString() != "gpu" {
+		resp.Diagnostics.AddAttributeError(
+			scaling.AtName("metric"),
+			"invalid scaling configuration",
+			fmt.Sprintf("the %s metric is not supported on %s endpoints, only on gpu endpoints", scalingMetricPendingRequests, accelerator.ValueString()),
+		)
+	}
+}
+
+// validatePrivateConfiguration checks that the private link is configured exactly for private
+// endpoints, which are only reachable through a private link to a cloud account.
+func validatePrivateConfiguration(endpointType types.String, private types.Object, diags *diag.Diagnostics) {
+	if endpointType.IsNull() || endpointType.IsUnknown() || private.IsUnknown() {
+		return
+	}
+
+	if endpointType.ValueString() == endpointTypePrivate && private.IsNull() {
+		diags.AddAttributeError(
+			path.Root("private"),
+			"missing private configuration",
+			"private must be set when type is "+endpointTypePrivate,
+		)
+	}
+	if endpointType.ValueString() != endpointTypePrivate && !private.IsNull() {
+		diags.AddAttributeError(
+			path.Root("private"),
+			"unexpected private configuration",
+			fmt.Sprintf("private can only be set when type is %s, not %s", endpointTypePrivate, endpointType.ValueString()),
+		)
+	}
+}
+
+// copyProviderOnlyAttributes copies the attributes that are not part of the API representation
+// of an endpoint from src to dst.
+func copyProviderOnlyAttributes(dst *endpointResourceModel, src endpointResourceModel) {
+	dst.OnFailure = src.OnFailure
+	dst.RollbackOnFailure = src.RollbackOnFailure
+	dst.NamePrefix = src.NamePrefix
+	dst.Namespace = src.Namespace
+	dst.Tags = src.Tags
+	dst.HealthCheck = src.HealthCheck
+	dst.Timeouts = src.Timeouts
+
+	// the private link is not part of every endpoint response
+	if dst.Private == nil {
+		dst.Private = src.Private
+	}
+
+	// a tracked revision is deployed as its resolved commit, which should not show up as a change
+	// of revision. An omitted revision is planned as unknown and keeps the deployed commit.
+	dst.Model.TrackRevision = src.Model.TrackRevision
+	dst.Model.ResolvedRevision = src.Model.ResolvedRevision
+	if src.Model.TrackRevision.ValueBool() && !src.Model.Revision.IsUnknown() && dst.Model.Revision.ValueString() == src.Model.ResolvedRevision.ValueString() {
+		dst.Model.Revision = src.Model.Revision
+	}
+
+	// a pinned image runs under its resolved url, which should not show up as a change of url
+	if dst.Model.Image.Custom != nil && src.Model.Image.Custom != nil {
+		dst.Model.Image.Custom.PinDigest = src.Model.Image.Custom.PinDigest
+		if src.Model.Image.Custom.PinDigest.ValueBool() && dst.Model.Image.Custom.URL == src.Model.Image.Custom.ResolvedURL.ValueString() {
+			dst.Model.Image.Custom.URL = src.Model.Image.Custom.URL
+		}
+	}
+}
+
+func (r *endpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.namespaces == nil {
+		return
+	}
+
+	hubModels := &hubModelCache{hub: r.hub}
+
+	r.modifyPlanDefaults(ctx, req, resp)
+	r.modifyPlanGuardrails(ctx, req, resp)
+	r.modifyPlanName(ctx, req, resp)
+	r.modifyPlanTags(ctx, req, resp)
+	r.modifyPlanImageDigest(ctx, req, resp)
+	r.modifyPlanRevision(ctx, req, resp, hubModels)
+	r.modifyPlanModelAccess(ctx, req, resp, hubModels)
+	r.modifyPlanModelMetadata(ctx, req, resp, hubModels)
+	r.modifyPlanFitCheck(ctx, req, resp, hubModels)
+	r.modifyPlanBudget(ctx, req, resp)
+}
+
+// modifyPlanDefaults fills the attributes omitted from the configuration with the endpoint
+// defaults of the provider, so that the plan shows the values the endpoint is deployed with.
+func (r *endpointResource) modifyPlanDefaults(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defaults := r.endpointDefaults
+	scaleToZeroTimeout := path.Root("compute").AtName("scaling").AtName("scale_to_zero_timeout")
+
+	var cloud, private types.Object
+	var endpointType types.String
+	var timeout types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("cloud"), &cloud)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("private"), &private)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &endpointType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, scaleToZeroTimeout, &timeout)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if cloud.IsNull() {
+		if defaults.Cloud == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("cloud"),
+				"missing cloud",
+				"cloud must be set on the endpoint or in the endpoint_defaults of the provider",
+			)
+		} else {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("cloud"), defaults.Cloud)...)
+
+			// the cloud of an endpoint cannot be updated, so a change of the default replaces it
+			var priorCloud *Cloud
+			if !req.State.Raw.IsNull() {
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("cloud"), &priorCloud)...)
+			}
+			if priorCloud != nil && *priorCloud != *defaults.Cloud {
+				resp.RequiresReplace.Append(path.Root("cloud"))
+			}
+		}
+	}
+
+	if endpointType.IsNull() {
+		if defaults.Type == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("type"),
+				"missing type",
+				"type must be set on the endpoint or in the endpoint_defaults of the provider",
+			)
+		} else {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), defaults.Type)...)
+			validatePrivateConfiguration(types.StringPointerValue(defaults.Type), private, &resp.Diagnostics)
+		}
+	}
+
+	if timeout.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, scaleToZeroTimeout, defaults.ScaleToZeroTimeout)...)
+	}
+
+	for _, image := range []string{"huggingface", "custom"} {
+		env := path.Root("model").AtName("image").AtName(image).AtName("env")
+
+		var imageConfig types.Object
+		var envConfig types.Map
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("model").AtName("image").AtName(image), &imageConfig)...)
+		if resp.Diagnostics.HasError() || imageConfig.IsNull() || imageConfig.IsUnknown() {
+			continue
+		}
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, env, &envConfig)...)
+		if resp.Diagnostics.HasError() || envConfig.IsUnknown() {
+			continue
+		}
+
+		// variables set on the endpoint take precedence over the common ones
+		if envConfig.IsNull() && len(defaults.Env) == 0 {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, env, envConfig)...)
+			continue
+		}
+		elements := make(map[string]attr.Value, len(defaults.Env)+len(envConfig.Elements()))
+		for name, value := range defaults.Env {
+			elements[name] = types.StringValue(value)
+		}
+		for name, value := range envConfig.Elements() {
+			elements[name] = value
+		}
+		merged, diags := types.MapValue(types.StringType, elements)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, env, merged)...)
+	}
+}
+
+// modifyPlanGuardrails fails the plan of an endpoint outside the namespaces, vendors and regions
+// the provider is allowed to manage.
+func (r *endpointResource) modifyPlanGuardrails(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var namespace, vendor, region types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("namespace"), &namespace)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("cloud").AtName("vendor"), &vendor)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("cloud").AtName("region"), &region)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// an endpoint without a namespace is created in the namespace of the provider
+	if namespace.IsUnknown() {
+		var config types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("namespace"), &config)...)
+		if config.IsNull() {
+			namespace = types.StringValue(r.namespaces.defaultNamespace)
+		}
+	}
+	if !namespace.IsUnknown() {
+		r.guardrails.checkNamespace(&resp.Diagnostics, path.Root("namespace"), namespace.ValueString())
+	}
+	if !vendor.IsUnknown() {
+		r.guardrails.checkVendor(&resp.Diagnostics, path.Root("cloud").AtName("vendor"), vendor.ValueString())
+	}
+	if !region.IsUnknown() {
+		r.guardrails.checkRegion(&resp.Diagnostics, path.Root("cloud").AtName("region"), region.ValueString())
+	}
+}
+
+// modifyPlanName warns about the replacement of an endpoint by one with the same name in the same
+// namespace. Both cannot exist at the same time: the endpoint is down until its replacement is
+// created, and with create_before_destroy the replacement takes over the endpoint, which is then
+// destroyed.
+func (r *endpointResource) modifyPlanName(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// a name generated from a prefix is only kept from state until the replacement is planned
+	var configName types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &configName)...)
+	if resp.Diagnostics.HasError() || configName.IsNull() {
+		return
+	}
+
+	var name, priorName, namespace, priorNamespace types.String
+	var cloud, priorCloud, private, priorPrivate types.Object
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &priorName)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("namespace"), &namespace)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("namespace"), &priorNamespace)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("cloud"), &cloud)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("cloud"), &priorCloud)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("private"), &private)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("private"), &priorPrivate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// endpoints of an older state without namespace live in the namespace of the provider
+	if priorNamespace.IsNull() {
+		priorNamespace = types.StringValue(r.namespaces.defaultNamespace)
+		if namespace.IsUnknown() {
+			var config types.String
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("namespace"), &config)...)
+			if config.IsNull() {
+				namespace = priorNamespace
+			}
+		}
+	}
+	if !name.Equal(priorName) || !namespace.Equal(priorNamespace) {
+		return
+	}
+	if cloud.Equal(priorCloud) && private.Equal(priorPrivate) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("name"),
+		"endpoint replaced under the same name",
+		"endpoint named "+name.ValueString()+" is replaced by an endpoint with the same name to change its cloud or "+
+			"private configuration, so both cannot exist at the same time. Without create_before_destroy the endpoint "+
+			"is down until its replacement is created, with it the replacement takes over the endpoint, which is then "+
+			"destroyed. Use name_prefix instead of name for blue/green replacements.",
+	)
+}
+
+// endpointReplaced reports whether plan replaces the endpoint in state, which happens when one of
+// the attributes that cannot be updated changes.
+func (r *endpointResource) endpointReplaced(ctx context.Context, req resource.ModifyPlanRequest, plan tfsdk.Plan) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if req.State.Raw.IsNull() {
+		return false, diags
+	}
+
+	for _, attribute := range []string{"name", "name_prefix", "namespace", "cloud", "private"} {
+		var planned, prior, config attr.Value
+		diags.Append(plan.GetAttribute(ctx, path.Root(attribute), &planned)...)
+		diags.Append(req.State.GetAttribute(ctx, path.Root(attribute), &prior)...)
+		diags.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &config)...)
+		if diags.HasError() {
+			return false, diags
+		}
+
+		// endpoints of an older state without namespace live in the namespace of the provider
+		if attribute == "namespace" && prior.IsNull() {
+			prior = types.StringValue(r.namespaces.defaultNamespace)
+			if planned.IsUnknown() && config.IsNull() {
+				planned = prior
+			}
+		}
+		if !planned.Equal(prior) {
+			return true, diags
+		}
+	}
+	return false, diags
+}
+
+// modifyPlanTags plans the tags of an endpoint, which are the default tags of the provider merged
+// with the tags of the resource.
+func (r *endpointResource) modifyPlanTags(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var tags types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if resp.Diagnostics.HasError() || tags.IsUnknown() {
+		return
+	}
+
+	var resourceTags []string
+	resp.Diagnostics.Append(tags.ElementsAs(ctx, &resourceTags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), mergeTags(r.defaultTags, resourceTags))...)
+}
+
+// modifyPlanImageDigest plans the resolved url of a custom image, pinning it to the current
+// digest of its tag when requested.
+func (r *endpointResource) modifyPlanImageDigest(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	custom := path.Root("model").AtName("image").AtName("custom")
+
+	var imageURL, username, password types.String
+	var pinDigest types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, custom.AtName("url"), &imageURL)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, custom.AtName("pin_digest"), &pinDigest)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, custom.AtName("credentials").AtName("username"), &username)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, custom.AtName("credentials").AtName("password"), &password)...)
+	if resp.Diagnostics.HasError() || imageURL.IsNull() || imageURL.IsUnknown() || pinDigest.IsUnknown() {
+		return
+	}
+
+	resolvedURL := imageURL.ValueString()
+	if pinDigest.ValueBool() {
+		var credentials *Credentials
+		if !username.IsNull() && !username.IsUnknown() && !password.IsUnknown() {
+			credentials = &Credentials{
+				Username: username.ValueString(),
+				Password: password.ValueString(),
+			}
+		}
+
+		var err error
+		resolvedURL, err = resolveImageDigest(ctx, r.httpClient, imageURL.ValueString(), credentials)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				custom.AtName("url"),
+				"error resolving image digest",
+				"could not resolve the digest of "+imageURL.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, custom.AtName("resolved_url"), resolvedURL)...)
+}
+
+// modifyPlanRevision plans the commit the model revision resolves to. The Hub is only asked
+// again when the revision is tracked or the model changed.
+func (r *endpointResource) modifyPlanRevision(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, hubModels *hubModelCache) {
+	model := path.Root("model")
+
+	var repository, revision types.String
+	var trackRevision types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, model.AtName("repository"), &repository)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, model.AtName("revision"), &revision)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, model.AtName("track_revision"), &trackRevision)...)
+	if resp.Diagnostics.HasError() || repository.IsUnknown() || revision.IsUnknown() || trackRevision.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() && !trackRevision.ValueBool() {
+		var stateRepository, stateRevision, stateResolvedRevision types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, model.AtName("repository"), &stateRepository)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, model.AtName("revision"), &stateRevision)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, model.AtName("resolved_revision"), &stateResolvedRevision)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if stateRepository.Equal(repository) && (revision.IsNull() || stateRevision.Equal(revision)) && !stateResolvedRevision.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, model.AtName("resolved_revision"), stateResolvedRevision)...)
+			return
+		}
+	}
+
+	ref := defaultModelRevision
+	if !revision.IsNull() {
+		ref = revision.ValueString()
+	}
+
+	hubModel, err := hubModels.get(ctx, repository.ValueString(), ref)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			model.AtName("repository"),
+			"error resolving model revision",
+			"could not resolve revision "+ref+": "+hubModelErrorDetail(repository.ValueString(), err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, model.AtName("resolved_revision"), hubModel.SHA)...)
+}
+
+// modifyPlanModelAccess fails the plan when the token cannot read the model repository or has
+// not been granted access to a gated one.
+func (r *endpointResource) modifyPlanModelAccess(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, hubModels *hubModelCache) {
+	model := path.Root("model")
+
+	var repository, revision types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, model.AtName("repository"), &repository)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, model.AtName("revision"), &revision)...)
+	if resp.Diagnostics.HasError() || repository.IsUnknown() || revision.IsUnknown() {
+		return
+	}
+
+	// only check again when the model changes
+	if !req.State.Raw.IsNull() {
+		var stateRepository, stateRevision types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, model.AtName("repository"), &stateRepository)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, model.AtName("revision"), &stateRevision)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if stateRepository.Equal(repository) && (revision.IsNull() || stateRevision.Equal(revision)) {
+			return
+		}
+	}
+
+	ref := defaultModelRevision
+	if !revision.IsNull() {
+		ref = revision.ValueString()
+	}
+
+	hubModel, err := hubModels.get(ctx, repository.ValueString(), ref)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			model.AtName("repository"),
+			"error reading model metadata",
+			hubModelErrorDetail(repository.ValueString(), err),
+		)
+		return
+	}
+
+	ok, err := r.hub.canDownloadModel(ctx, hubModel, ref)
+	if err != nil {
+		tflog.Warn(ctx, "skipping model access check", map[string]any{"repository": repository.ValueString(), "error": err.Error()})
+		return
+	}
+	if !ok {
+		resp.Diagnostics.AddAttributeError(
+			model.AtName("repository"),
+			"no access to model repository",
+			"token has no access to "+repository.ValueString()+" (gated), accept its licence on the Hub with the account of the token",
+		)
+	}
+}
+
+// modifyPlanModelMetadata fills in the task and framework of the model from its Hub metadata
+// when they are not configured.
+func (r *endpointResource) modifyPlanModelMetadata(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, hubModels *hubModelCache) {
+	model := path.Root("model")
+
+	var repository, revision, task, framework types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, model.AtName("repository"), &repository)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, model.AtName("revision"), &revision)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, model.AtName("task"), &task)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, model.AtName("framework"), &framework)...)
+	if resp.Diagnostics.HasError() || repository.IsUnknown() || revision.IsUnknown() || task.IsUnknown() || framework.IsUnknown() {
+		return
+	}
+	if !task.IsNull() && !framework.IsNull() {
+		return
+	}
+
+	// keep what was inferred before as long as the repository stays the same
+	if !req.State.Raw.IsNull() {
+		var stateRepository, stateTask, stateFramework types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, model.AtName("repository"), &stateRepository)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, model.AtName("task"), &stateTask)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, model.AtName("framework"), &stateFramework)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if stateRepository.Equal(repository) {
+			if task.IsNull() {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, model.AtName("task"), stateTask)...)
+			}
+			if framework.IsNull() {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, model.AtName("framework"), stateFramework)...)
+			}
+			return
+		}
+	}
+
+	ref := defaultModelRevision
+	if !revision.IsNull() {
+		ref = revision.ValueString()
+	}
+
+	hubModel, err := hubModels.get(ctx, repository.ValueString(), ref)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			model.AtName("repository"),
+			"error reading model metadata",
+			hubModelErrorDetail(repository.ValueString(), err),
+		)
+		return
+	}
+
+	if task.IsNull() {
+		if hubModel.PipelineTag == "" {
+			resp.Diagnostics.AddAttributeError(
+				model.AtName("task"),
+				"could not infer model task",
+				"the Hub has no pipeline tag for "+repository.ValueString()+", set model.task explicitly",
+			)
+		} else {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, model.AtName("task"), hubModel.PipelineTag)...)
+		}
+	}
+	if framework.IsNull() {
+		if hubModel.LibraryName == "" {
+			resp.Diagnostics.AddAttributeError(
+				model.AtName("framework"),
+				"could not infer model framework",
+				"the Hub has no library name for "+repository.ValueString()+", set model.framework explicitly",
+			)
+		} else {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, model.AtName("framework"), frameworkForLibrary(hubModel.LibraryName))...)
+		}
+	}
+}
+
+// modifyPlanFitCheck compares the size of the safetensors weights of the model with the memory
+// of the selected instance, warning or, under strict_fit_check, failing when they cannot fit.
+func (r *endpointResource) modifyPlanFitCheck(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, hubModels *hubModelCache) {
+	compute := path.Root("compute")
+	cloud := path.Root("cloud")
+	model := path.Root("model")
+
+	var accelerator, instanceType, instanceSize, vendor, region, repository, revision types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, compute.AtName("accelerator"), &accelerator)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, compute.AtName("instance_type"), &instanceType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, compute.AtName("instance_size"), &instanceSize)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, cloud.AtName("vendor"), &vendor)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, cloud.AtName("region"), &region)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, model.AtName("repository"), &repository)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, model.AtName("revision"), &revision)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, value := range []types.String{accelerator, instanceType, instanceSize, vendor, region, repository, revision} {
+		if value.IsUnknown() {
+			return
+		}
+	}
+
+	// only check again when the model or the instance changes
+	if !req.State.Raw.IsNull() {
+		var stateInstanceType, stateInstanceSize, stateRepository types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, compute.AtName("instance_type"), &stateInstanceType)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, compute.AtName("instance_size"), &stateInstanceSize)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, model.AtName("repository"), &stateRepository)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if stateInstanceType.Equal(instanceType) && stateInstanceSize.Equal(instanceSize) && stateRepository.Equal(repository) {
+			return
+		}
+	}
+
+	ref := defaultModelRevision
+	if !revision.IsNull() {
+		ref = revision.ValueString()
+	}
+
+	hubModel, err := hubModels.get(ctx, repository.ValueString(), ref)
+	if err != nil {
+		tflog.Warn(ctx, "skipping fit check, could not read model metadata", map[string]any{"repository": repository.ValueString(), "error": err.Error()})
+		return
+	}
+	weightsBytes := hubModel.safetensorsBytes()
+	if weightsBytes == 0 {
+		return
+	}
+
+	instance, err := r.catalogues.getComputeInstance(ctx, vendor.ValueString(), region.ValueString(), accelerator.ValueString(), instanceType.ValueString(), instanceSize.ValueString())
+	if err != nil {
+		tflog.Warn(ctx, "skipping fit check, could not read compute catalogue", map[string]any{"error": err.Error()})
+		return
+	}
+
+	memoryGb := instance.MemoryGb
+	if instance.Accelerator == "gpu" {
+		memoryGb = instance.GpuMemoryGb
+	}
+	weightsGb := float64(weightsBytes) / 1e9
+	if memoryGb == 0 || weightsGb <= memoryGb {
+		return
+	}
+
+	summary := "model does not fit on instance"
+	detail := fmt.Sprintf(
+		"the weights of %s take %.1f GB but %s %s instances have %.0f GB of %s memory",
+		repository.ValueString(), weightsGb, instanceType.ValueString(), instanceSize.ValueString(), memoryGb, instance.Accelerator,
+	)
+	if r.strictFitCheck {
+		resp.Diagnostics.AddAttributeError(compute.AtName("instance_size"), summary, detail)
+	} else {
+		resp.Diagnostics.AddAttributeWarning(compute.AtName("instance_size"), summary, detail)
+	}
+}
+
+// modifyPlanBudget fails the plan of an endpoint whose hourly cost at its maximum number of
+// replicas exceeds the limits of the provider, alone or together with the endpoints planned
+// before it.
+func (r *endpointResource) modifyPlanBudget(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !r.budget.enabled() {
+		return
+	}
+
+	compute := path.Root("compute")
+	cloud := path.Root("cloud")
+
+	var accelerator, instanceType, instanceSize, vendor, region, namespace, name, namePrefix types.String
+	var maxReplica types.Int64
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, compute.AtName("accelerator"), &accelerator)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, compute.AtName("instance_type"), &instanceType)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, compute.AtName("instance_size"), &instanceSize)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, compute.AtName("scaling").AtName("max_replica"), &maxReplica)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, cloud.AtName("vendor"), &vendor)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, cloud.AtName("region"), &region)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("namespace"), &namespace)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("name_prefix"), &namePrefix)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, value := range []types.String{accelerator, instanceType, instanceSize, vendor, region} {
+		if value.IsUnknown() {
+			return
+		}
+	}
+	if maxReplica.IsUnknown() {
+		return
+	}
+
+	instance, err := r.catalogues.getComputeInstance(ctx, vendor.ValueString(), region.ValueString(), accelerator.ValueString(), instanceType.ValueString(), instanceSize.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			compute.AtName("instance_size"),
+			"error reading instance price",
+			"could not read the price of the instance from the compute catalogue, which is needed to check the hourly cost limits: "+err.Error(),
+		)
+		return
+	}
+
+	if namespace.IsUnknown() {
+		namespace = types.StringValue(r.namespaces.defaultNamespace)
+	}
+	cost := endpointCost{
+		name:       namespace.ValueString() + "/" + name.ValueString(),
+		instance:   instance,
+		maxReplica: int(maxReplica.ValueInt64()),
+	}
+	if name.IsUnknown() {
+		cost.name = namespace.ValueString() + "/" + namePrefix.ValueString() + "*"
+	}
+
+	// the create of a replacement is planned with the name of the endpoint it replaces, or with
+	// the same name prefix and instance when its name is generated
+	replacement := cost.name
+	if !namePrefix.IsNull() {
+		replacement = fmt.Sprintf(
+			"%s/%s* %s %s %s %s %s x%d",
+			namespace.ValueString(), namePrefix.ValueString(), vendor.ValueString(), region.ValueString(),
+			accelerator.ValueString(), instanceType.ValueString(), instanceSize.ValueString(), cost.maxReplica,
+		)
+	}
+
+	if limit := r.budget.maxPerEndpoint; limit != nil && cost.hourly() > *limit {
+		breakdown, _ := costBreakdown([]endpointCost{cost})
+		resp.Diagnostics.AddAttributeError(
+			compute,
+			"endpoint exceeds hourly cost limit",
+			fmt.Sprintf("endpoint costs $%.2f/h at max_replica, above the max_hourly_cost_per_endpoint of $%.2f/h:\n%s", cost.hourly(), *limit, breakdown),
+		)
+	}
+
+	var costs []endpointCost
+	if req.State.Raw.IsNull() {
+		costs = r.budget.addNew(replacement, cost)
+	} else {
+		// endpoints in state are told apart by the name they have in state
+		var priorNamespace, priorName types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("namespace"), &priorNamespace)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &priorName)...)
+		replaced, diags := r.endpointReplaced(ctx, req, resp.Plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if priorNamespace.IsNull() {
+			priorNamespace = types.StringValue(r.namespaces.defaultNamespace)
+		}
+		if !replaced {
+			replacement = ""
+		}
+		costs = r.budget.add(priorNamespace.ValueString()+"/"+priorName.ValueString(), replacement, cost)
+	}
+	if limit := r.budget.maxTotal; limit != nil {
+		breakdown, total := costBreakdown(costs)
+		if total > *limit {
+			resp.Diagnostics.AddAttributeError(
+				compute,
+				"endpoints exceed total hourly cost limit",
+				fmt.Sprintf("endpoints cost $%.2f/h at max_replica, above the max_hourly_cost_total of $%.2f/h:\n%s", total, *limit, breakdown),
+			)
+		}
+	}
+}
+
+// mergeTags returns the sorted union of the given tags.
+func mergeTags(tagSets ...[]string) []string {
+	merged := []string{}
+	seen := map[string]bool{}
+	for _, tags := range tagSets {
+		for _, tag := range tags {
+			if !seen[tag] {
+				seen[tag] = true
+				merged = append(merged, tag)
+			}
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// generateEndpointName returns a unique endpoint name made of prefix and a random suffix.
+func generateEndpointName(prefix string) (string, error) {
+	suffix := make([]byte, endpointNameSuffixLength/2)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(suffix), nil
+}
+
+// requestRevision returns the revision to deploy, which is the resolved commit of a tracked
+// revision.
+func requestRevision(model Model) *string {
+	if model.TrackRevision.ValueBool() && model.ResolvedRevision.ValueString() != "" {
+		return model.ResolvedRevision.ValueStringPointer()
+	}
+	return model.Revision.ValueStringPointer()
+}
+
+func clientImageToProviderImage(clientImage endpointImage) Image {
 	var image Image
-	if endpoint.Model.Image.Huggingface != nil {
+	if clientImage.Huggingface != nil {
 		image = Image{
 			Huggingface: &Huggingface{
-				Env: endpoint.Model.Image.Huggingface.Env,
+				Env: clientImage.Huggingface.Env,
 			},
 		}
-	} else if endpoint.Model.Image.Custom != nil {
+		if image.Huggingface.Env == nil {
+			image.Huggingface.Env = make(map[string]string)
+		}
+	} else if clientImage.Custom != nil {
 		image = Image{
 			Custom: &Custom{
-				Env:         endpoint.Model.Image.Custom.Env,
-				HealthRoute: endpoint.Model.Image.Custom.HealthRoute,
-				Port:        endpoint.Model.Image.Custom.Port,
-				URL:         endpoint.Model.Image.Custom.URL,
+				Args:        clientImage.Custom.Args,
+				Command:     clientImage.Custom.Command,
+				Env:         clientImage.Custom.Env,
+				HealthRoute: clientImage.Custom.HealthRoute,
+				PinDigest:   types.BoolValue(false),
+				Port:        clientImage.Custom.Port,
+				ResolvedURL: types.StringValue(clientImage.Custom.URL),
+				URL:         clientImage.Custom.URL,
 			},
 		}
-		if endpoint.Model.Image.Custom.Credentials != nil {
+		if clientImage.Custom.Credentials != nil {
 			image.Custom.Credentials = &Credentials{
-				Username: endpoint.Model.Image.Custom.Credentials.Username,
-				Password: endpoint.Model.Image.Custom.Credentials.Password,
+				Username: clientImage.Custom.Credentials.Username,
+				Password: clientImage.Custom.Credentials.Password,
+			}
+		}
+		if image.Custom.Env == nil {
+			image.Custom.Env = make(map[string]string)
+		}
+	} else if clientImage.Tgi != nil {
+		image = Image{
+			Tgi: &Tgi{
+				DisableCustomKernels:  clientImage.Tgi.DisableCustomKernels,
+				HealthRoute:           clientImage.Tgi.HealthRoute,
+				MaxBatchPrefillTokens: clientImage.Tgi.MaxBatchPrefillTokens,
+				MaxBatchTotalTokens:   clientImage.Tgi.MaxBatchTotalTokens,
+				MaxInputTokens:        clientImage.Tgi.MaxInputLength,
+				MaxTotalTokens:        clientImage.Tgi.MaxTotalTokens,
+				Port:                  clientImage.Tgi.Port,
+				Quantize:              clientImage.Tgi.Quantize,
+				URL:                   clientImage.Tgi.URL,
+			},
+		}
+	} else if clientImage.Tei != nil {
+		image = Image{
+			Tei: &Tei{
+				HealthRoute:           clientImage.Tei.HealthRoute,
+				MaxBatchTokens:        clientImage.Tei.MaxBatchTokens,
+				MaxConcurrentRequests: clientImage.Tei.MaxConcurrentRequests,
+				Pooling:               clientImage.Tei.Pooling,
+				Port:                  clientImage.Tei.Port,
+				URL:                   clientImage.Tei.URL,
+			},
+		}
+	} else if clientImage.Vllm != nil {
+		image = Image{
+			Vllm: &Vllm{
+				HealthRoute:         clientImage.Vllm.HealthRoute,
+				KvCacheDtype:        clientImage.Vllm.KvCacheDtype,
+				MaxModelLen:         clientImage.Vllm.MaxModelLen,
+				MaxNumBatchedTokens: clientImage.Vllm.MaxNumBatchedTokens,
+				MaxNumSeqs:          clientImage.Vllm.MaxNumSeqs,
+				Port:                clientImage.Vllm.Port,
+				TensorParallelSize:  clientImage.Vllm.TensorParallelSize,
+				URL:                 clientImage.Vllm.URL,
+			},
+		}
+	} else if clientImage.Llamacpp != nil {
+		image = Image{
+			Llamacpp: &Llamacpp{
+				CtxSize:     clientImage.Llamacpp.CtxSize,
+				Embeddings:  clientImage.Llamacpp.Embeddings,
+				GGUFFile:    clientImage.Llamacpp.ModelPath,
+				HealthRoute: clientImage.Llamacpp.HealthRoute,
+				NParallel:   clientImage.Llamacpp.NParallel,
+				Pooling:     clientImage.Llamacpp.Pooling,
+				Port:        clientImage.Llamacpp.Port,
+				ThreadsHttp: clientImage.Llamacpp.ThreadsHttp,
+				URL:         clientImage.Llamacpp.URL,
+			},
+		}
+	}
+	return image
+}
+
+func providerImageToClientImage(image Image) endpointImage {
+	var clientImage endpointImage
+	if image.Huggingface != nil {
+		clientImage.Huggingface = &huggingface.Huggingface{
+			Env: image.Huggingface.Env,
+		}
+	} else if image.Custom != nil {
+		imageURL := image.Custom.URL
+		if image.Custom.PinDigest.ValueBool() && image.Custom.ResolvedURL.ValueString() != "" {
+			imageURL = image.Custom.ResolvedURL.ValueString()
+		}
+		clientImage.Custom = &customImage{
+			Custom: huggingface.Custom{
+				Env:         image.Custom.Env,
+				HealthRoute: image.Custom.HealthRoute,
+				Port:        image.Custom.Port,
+				URL:         imageURL,
+			},
+			Args:    image.Custom.Args,
+			Command: image.Custom.Command,
+		}
+		if image.Custom.Credentials != nil {
+			clientImage.Custom.Credentials = &huggingface.Credentials{
+				Username: image.Custom.Credentials.Username,
+				Password: image.Custom.Credentials.Password,
 			}
 		}
+	} else if image.Tgi != nil {
+		clientImage.Tgi = &tgiImage{
+			DisableCustomKernels:  image.Tgi.DisableCustomKernels,
+			HealthRoute:           image.Tgi.HealthRoute,
+			MaxBatchPrefillTokens: image.Tgi.MaxBatchPrefillTokens,
+			MaxBatchTotalTokens:   image.Tgi.MaxBatchTotalTokens,
+			MaxInputLength:        image.Tgi.MaxInputTokens,
+			MaxTotalTokens:        image.Tgi.MaxTotalTokens,
+			Port:                  image.Tgi.Port,
+			Quantize:              image.Tgi.Quantize,
+			URL:                   image.Tgi.URL,
+		}
+	} else if image.Tei != nil {
+		clientImage.Tei = &teiImage{
+			HealthRoute:           image.Tei.HealthRoute,
+			MaxBatchTokens:        image.Tei.MaxBatchTokens,
+			MaxConcurrentRequests: image.Tei.MaxConcurrentRequests,
+			Pooling:               image.Tei.Pooling,
+			Port:                  image.Tei.Port,
+			URL:                   image.Tei.URL,
+		}
+	} else if image.Vllm != nil {
+		clientImage.Vllm = &vllmImage{
+			HealthRoute:         image.Vllm.HealthRoute,
+			KvCacheDtype:        image.Vllm.KvCacheDtype,
+			MaxModelLen:         image.Vllm.MaxModelLen,
+			MaxNumBatchedTokens: image.Vllm.MaxNumBatchedTokens,
+			MaxNumSeqs:          image.Vllm.MaxNumSeqs,
+			Port:                image.Vllm.Port,
+			TensorParallelSize:  image.Vllm.TensorParallelSize,
+			URL:                 image.Vllm.URL,
+		}
+	} else if image.Llamacpp != nil {
+		clientImage.Llamacpp = &llamacppImage{
+			CtxSize:     image.Llamacpp.CtxSize,
+			Embeddings:  image.Llamacpp.Embeddings,
+			HealthRoute: image.Llamacpp.HealthRoute,
+			ModelPath:   image.Llamacpp.GGUFFile,
+			NParallel:   image.Llamacpp.NParallel,
+			Pooling:     image.Llamacpp.Pooling,
+			Port:        image.Llamacpp.Port,
+			ThreadsHttp: image.Llamacpp.ThreadsHttp,
+			URL:         image.Llamacpp.URL,
+		}
 	}
+	return clientImage
+}
 
-	providerEndpoint := endpointResourceModel{
-		AccountId: types.StringPointerValue(endpoint.AccountId),
-		Compute: Compute{
-			Accelerator:  endpoint.Compute.Accelerator,
-			InstanceSize: endpoint.Compute.InstanceSize,
-			InstanceType: endpoint.Compute.InstanceType,
-			Scaling: Scaling{
-				MaxReplica:         endpoint.Compute.Scaling.MaxReplica,
-				MinReplica:         endpoint.Compute.Scaling.MinReplica,
-				ScaleToZeroTimeout: endpoint.Compute.Scaling.ScaleToZeroTimeout,
+// scalingMetrics maps the autoscaling metrics of the provider to their API names.
+var scalingMetrics = map[string]string{
+	scalingMetricHardwareUsage:   "hardwareUsage",
+	scalingMetricPendingRequests: "pendingRequests",
+}
+
+func clientComputeToProviderCompute(clientCompute endpointCompute) Compute {
+	compute := Compute{
+		Accelerator:  clientCompute.Accelerator,
+		InstanceSize: clientCompute.InstanceSize,
+		InstanceType: clientCompute.InstanceType,
+		Scaling: Scaling{
+			MaxReplica:         clientCompute.Scaling.MaxReplica,
+			MinReplica:         clientCompute.Scaling.MinReplica,
+			ScaleToZeroTimeout: clientCompute.Scaling.ScaleToZeroTimeout,
+		},
+	}
+	if clientCompute.Scaling.Measure != nil {
+		compute.Scaling.Measure = &Measure{
+			HardwareUsage:   clientCompute.Scaling.Measure.HardwareUsage,
+			PendingRequests: clientCompute.Scaling.Measure.PendingRequests,
+		}
+	}
+	if clientCompute.Scaling.Metric != nil {
+		for metric, apiMetric := range scalingMetrics {
+			if apiMetric == *clientCompute.Scaling.Metric {
+				compute.Scaling.Metric = &metric
+			}
+		}
+	}
+	return compute
+}
+
+func providerComputeToClientCompute(compute Compute) endpointCompute {
+	clientCompute := endpointCompute{
+		Compute: huggingface.Compute{
+			Accelerator:  compute.Accelerator,
+			InstanceSize: compute.InstanceSize,
+			InstanceType: compute.InstanceType,
+		},
+		Scaling: endpointScaling{
+			Scaling: huggingface.Scaling{
+				MaxReplica:         compute.Scaling.MaxReplica,
+				MinReplica:         compute.Scaling.MinReplica,
+				ScaleToZeroTimeout: compute.Scaling.ScaleToZeroTimeout,
 			},
 		},
+	}
+	if compute.Scaling.Measure != nil {
+		clientCompute.Scaling.Measure = &scalingMeasure{
+			HardwareUsage:   compute.Scaling.Measure.HardwareUsage,
+			PendingRequests: compute.Scaling.Measure.PendingRequests,
+		}
+	}
+	if compute.Scaling.Metric != nil {
+		metric := scalingMetrics[*compute.Scaling.Metric]
+		clientCompute.Scaling.Metric = &metric
+	}
+	return clientCompute
+}
+
+func clientEndpointToProviderEndpoint(endpoint endpointDetails) endpointResourceModel {
+	providerEndpoint := endpointResourceModel{
+		AccountId: types.StringPointerValue(endpoint.AccountId),
+		Compute:   clientComputeToProviderCompute(endpoint.Compute),
 		Model: Model{
-			Framework:  endpoint.Model.Framework,
-			Image:      image,
+			Framework:  types.StringValue(endpoint.Model.Framework),
+			Image:      clientImageToProviderImage(endpoint.Model.Image),
 			Repository: endpoint.Model.Repository,
 			Revision:   types.StringPointerValue(endpoint.Model.Revision),
 			Task:       types.StringPointerValue(endpoint.Model.Task),
+
+			ResolvedRevision: types.StringNull(),
+			TrackRevision:    types.BoolValue(false),
 		},
 		Name: types.StringValue(endpoint.Name),
 		Cloud: Cloud{
 			Region: endpoint.Provider.Region,
 			Vendor: endpoint.Provider.Vendor,
 		},
-		Type: types.StringValue(endpoint.Type),
+		Type:               types.StringValue(endpoint.Type),
+		PrivateServiceName: types.StringNull(),
+		TagsAll:            mergeTags(endpoint.Tags),
+		Status: &Status{
+			CreatedAt: endpoint.Status.CreatedAt,
+			CreatedBy: User{
+				ID:   endpoint.Status.CreatedBy.ID,
+				Name: endpoint.Status.CreatedBy.Name,
+			},
+			ErrorMessage: endpoint.Status.ErrorMessage,
+			Message:      endpoint.Status.Message,
+			Private: Private{
+				ServiceName: endpoint.Status.Private.ServiceName,
+			},
+			ReadyReplica:  endpoint.Status.ReadyReplica,
+			State:         endpoint.Status.State,
+			TargetReplica: endpoint.Status.TargetReplica,
+			UpdatedAt:     endpoint.Status.UpdatedAt,
+			UpdatedBy: User{
+				ID:   endpoint.Status.UpdatedBy.ID,
+				Name: endpoint.Status.UpdatedBy.Name,
+			},
+			URL: endpoint.Status.URL,
+		},
 	}
-
-	if endpoint.Model.Image.Huggingface != nil && endpoint.Model.Image.Huggingface.Env == nil {
-		providerEndpoint.Model.Image.Huggingface.Env = make(map[string]string)
+	if endpoint.Private != nil {
+		providerEndpoint.Private = &PrivateEndpoint{
+			AccountId: endpoint.Private.AccountId,
+			Shared:    types.BoolValue(endpoint.Private.Shared),
+		}
 	}
-
-	if endpoint.Model.Image.Custom != nil && endpoint.Model.Image.Custom.Env == nil {
-		providerEndpoint.Model.Image.Custom.Env = make(map[string]string)
+	if endpoint.Status.Private.ServiceName != "" {
+		providerEndpoint.PrivateServiceName = types.StringValue(endpoint.Status.Private.ServiceName)
 	}
 
 	return providerEndpoint
 }
 
-func providerEndpointToCreateEndpointRequest(endpoint endpointResourceModel) huggingface.CreateEndpointRequest {
-	var image huggingface.Image
-	if endpoint.Model.Image.Huggingface != nil {
-		image = huggingface.Image{
-			Huggingface: &huggingface.Huggingface{
-				Env: endpoint.Model.Image.Huggingface.Env,
-			},
-		}
-	} else if endpoint.Model.Image.Custom != nil {
-		image = huggingface.Image{
-			Custom: &huggingface.Custom{
-				Env:         endpoint.Model.Image.Custom.Env,
-				HealthRoute: endpoint.Model.Image.Custom.HealthRoute,
-				Port:        endpoint.Model.Image.Custom.Port,
-				URL:         endpoint.Model.Image.Custom.URL,
-			},
-		}
-		if endpoint.Model.Image.Custom.Credentials != nil {
-			image.Custom.Credentials = &huggingface.Credentials{
-				Username: endpoint.Model.Image.Custom.Credentials.Username,
-				Password: endpoint.Model.Image.Custom.Credentials.Password,
-			}
-		}
-	}
-
-	huggingfaceEndpoint := huggingface.CreateEndpointRequest{
-		Name:      endpoint.Name.ValueString(),
-		AccountId: endpoint.AccountId.ValueStringPointer(),
-		Compute: huggingface.Compute{
-			Accelerator:  endpoint.Compute.Accelerator,
-			InstanceSize: endpoint.Compute.InstanceSize,
-			InstanceType: endpoint.Compute.InstanceType,
-			Scaling: huggingface.Scaling{
-				MaxReplica:         endpoint.Compute.Scaling.MaxReplica,
-				MinReplica:         endpoint.Compute.Scaling.MinReplica,
-				ScaleToZeroTimeout: endpoint.Compute.Scaling.ScaleToZeroTimeout,
+func providerEndpointToCreateEndpointRequest(endpoint endpointResourceModel) createEndpointRequest {
+	huggingfaceEndpoint := createEndpointRequest{
+		CreateEndpointRequest: huggingface.CreateEndpointRequest{
+			Name:      endpoint.Name.ValueString(),
+			AccountId: endpoint.AccountId.ValueStringPointer(),
+			Provider: huggingface.Provider{
+				Region: endpoint.Cloud.Region,
+				Vendor: endpoint.Cloud.Vendor,
 			},
+			Type: endpoint.Type.ValueString(),
 		},
-		Model: huggingface.Model{
-			Framework:  endpoint.Model.Framework,
-			Image:      image,
-			Repository: endpoint.Model.Repository,
-			Revision:   endpoint.Model.Revision.ValueStringPointer(),
-			Task:       endpoint.Model.Task.ValueStringPointer(),
-		},
-		Provider: huggingface.Provider{
-			Region: endpoint.Cloud.Region,
-			Vendor: endpoint.Cloud.Vendor,
+		Compute: providerComputeToClientCompute(endpoint.Compute),
+		Tags:    endpoint.TagsAll,
+		Model: endpointModel{
+			Model: huggingface.Model{
+				Framework:  endpoint.Model.Framework.ValueString(),
+				Repository: endpoint.Model.Repository,
+				Revision:   requestRevision(endpoint.Model),
+				Task:       endpoint.Model.Task.ValueStringPointer(),
+			},
+			Image: providerImageToClientImage(endpoint.Model.Image),
 		},
-		Type: endpoint.Type.ValueString(),
+	}
+	if endpoint.Private != nil {
+		huggingfaceEndpoint.Private = &endpointPrivate{
+			AccountId: endpoint.Private.AccountId,
+			Shared:    endpoint.Private.Shared.ValueBool(),
+		}
 	}
 
 	return huggingfaceEndpoint
 }
 
-func providerEndpointToUpdateEndpointRequest(endpoint endpointResourceModel) huggingface.UpdateEndpointRequest {
-	var image huggingface.Image
-	if endpoint.Model.Image.Huggingface != nil {
-		image = huggingface.Image{
-			Huggingface: &huggingface.Huggingface{
-				Env: endpoint.Model.Image.Huggingface.Env,
-			},
-		}
-	} else if endpoint.Model.Image.Custom != nil {
-		image = huggingface.Image{
-			Custom: &huggingface.Custom{
-				Env:         endpoint.Model.Image.Custom.Env,
-				HealthRoute: endpoint.Model.Image.Custom.HealthRoute,
-				Port:        endpoint.Model.Image.Custom.Port,
-				URL:         endpoint.Model.Image.Custom.URL,
-			},
-		}
-		if endpoint.Model.Image.Custom.Credentials != nil {
-			image.Custom.Credentials = &huggingface.Credentials{
-				Username: endpoint.Model.Image.Custom.Credentials.Username,
-				Password: endpoint.Model.Image.Custom.Credentials.Password,
-			}
-		}
-	}
-
-	huggingfaceEndpoint := huggingface.UpdateEndpointRequest{
-		Compute: &huggingface.Compute{
-			Accelerator:  endpoint.Compute.Accelerator,
-			InstanceSize: endpoint.Compute.InstanceSize,
-			InstanceType: endpoint.Compute.InstanceType,
-			Scaling: huggingface.Scaling{
-				MaxReplica:         endpoint.Compute.Scaling.MaxReplica,
-				MinReplica:         endpoint.Compute.Scaling.MinReplica,
-				ScaleToZeroTimeout: endpoint.Compute.Scaling.ScaleToZeroTimeout,
-			},
+func providerEndpointToUpdateEndpointRequest(endpoint endpointResourceModel) updateEndpointRequest {
+	compute := providerComputeToClientCompute(endpoint.Compute)
+	huggingfaceEndpoint := updateEndpointRequest{
+		UpdateEndpointRequest: huggingface.UpdateEndpointRequest{
+			Type: endpoint.Type.ValueStringPointer(),
 		},
-		Model: &huggingface.Model{
-			Framework:  endpoint.Model.Framework,
-			Image:      image,
-			Repository: endpoint.Model.Repository,
-			Revision:   endpoint.Model.Revision.ValueStringPointer(),
-			Task:       endpoint.Model.Task.ValueStringPointer(),
+		Compute: &compute,
+		Tags:    mergeTags(endpoint.TagsAll),
+		Model: &endpointModel{
+			Model: huggingface.Model{
+				Framework:  endpoint.Model.Framework.ValueString(),
+				Repository: endpoint.Model.Repository,
+				Revision:   requestRevision(endpoint.Model),
+				Task:       endpoint.Model.Task.ValueStringPointer(),
+			},
+			Image: providerImageToClientImage(endpoint.Model.Image),
 		},
-		Type: endpoint.Type.ValueStringPointer(),
 	}
 
 	return huggingfaceEndpoint
@@ -342,31 +1806,59 @@ func (r *endpointResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	existingEndpoints, err := r.client.ListEndpoints()
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := plan.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Name.IsUnknown() {
+		name, err := generateEndpointName(plan.NamePrefix.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"error generating endpoint name",
+				err.Error(),
+			)
+			return
+		}
+		plan.Name = types.StringValue(name)
+	}
+
+	if plan.Namespace.IsUnknown() {
+		plan.Namespace = types.StringValue(r.namespaces.defaultNamespace)
+	}
+	clients, err := r.clientsFor(plan.Namespace.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"error listing endpoints",
+			"unable to create huggingface api client",
 			err.Error(),
 		)
 		return
 	}
 
-	useUpdate := false
-	for _, existingEndpoint := range existingEndpoints {
-		if existingEndpoint.Name == plan.Name.ValueString() {
-			useUpdate = true
-			break
-		}
+	useUpdate, err := r.endpointExists(clients, plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error listing endpoints",
+			err.Error(),
+		)
+		return
 	}
 
-	var createdEndpoint huggingface.EndpointDetails
+	var createdEndpoint endpointDetails
 
 	if useUpdate {
 		updateEndpointRequest := providerEndpointToUpdateEndpointRequest(plan)
-		createdEndpoint, err = r.client.UpdateEndpoint(plan.Name.ValueString(), updateEndpointRequest)
+		createdEndpoint, err = clients.api.updateEndpoint(ctx, plan.Name.ValueString(), updateEndpointRequest)
 	} else {
 		createEndpointRequest := providerEndpointToCreateEndpointRequest(plan)
-		createdEndpoint, err = r.client.CreateEndpoint(createEndpointRequest)
+		createdEndpoint, err = clients.api.createEndpoint(ctx, createEndpointRequest)
 	}
 
 	if err != nil {
@@ -377,7 +1869,45 @@ func (r *endpointResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	plan = clientEndpointToProviderEndpoint(createdEndpoint)
+	createdEndpoint = r.waitForEndpointReady(ctx, clients, createTimeout, createdEndpoint, &resp.Diagnostics)
+	if detail := r.checkEndpoint(ctx, clients, createdEndpoint, plan.HealthCheck); detail != "" {
+		// an adopted endpoint was not created by this apply, so it is tainted rather than deleted
+		onFailure := plan.OnFailure.ValueString()
+		if onFailure == onFailureDelete && useUpdate {
+			onFailure = onFailureTaint
+			detail += "\n\nthe endpoint existed before it was adopted and has not been deleted"
+		}
+
+		switch onFailure {
+		case onFailureDelete:
+			err = r.deleteEndpoint(ctx, clients, createdEndpoint.Name, deleteTimeout)
+			if err == nil {
+				resp.Diagnostics.AddError(
+					"endpoint failed",
+					detail+"\n\nthe endpoint has been deleted",
+				)
+				return
+			}
+			resp.Diagnostics.AddError(
+				"endpoint failed",
+				detail+"\n\ncould not delete the endpoint: "+err.Error(),
+			)
+		case onFailureKeep:
+			resp.Diagnostics.AddWarning(
+				"endpoint failed",
+				detail+"\n\nthe endpoint has been kept",
+			)
+		default:
+			resp.Diagnostics.AddError(
+				"endpoint failed",
+				detail,
+			)
+		}
+	}
+
+	newPlan := clientEndpointToProviderEndpoint(createdEndpoint)
+	copyProviderOnlyAttributes(&newPlan, plan)
+	plan = newPlan
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -394,7 +1924,20 @@ func (r *endpointResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	endpoint, err := r.client.GetEndpoint(state.Name.ValueString())
+	// endpoints created before namespaces could be set live in the namespace of the provider
+	if state.Namespace.IsNull() {
+		state.Namespace = types.StringValue(r.namespaces.defaultNamespace)
+	}
+	clients, err := r.clientsFor(state.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"unable to create huggingface api client",
+			err.Error(),
+		)
+		return
+	}
+
+	endpoint, err := clients.api.getEndpoint(ctx, state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"error reading endpoint",
@@ -403,7 +1946,24 @@ func (r *endpointResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	state = clientEndpointToProviderEndpoint(endpoint)
+	newState := clientEndpointToProviderEndpoint(endpoint)
+	copyProviderOnlyAttributes(&newState, state)
+	state = newState
+
+	// endpoints created before revisions were resolved have no resolved revision in state, it is
+	// read for the deployed revision so that it does not show up as a change in the next plan
+	if state.Model.ResolvedRevision.IsNull() && r.hub != nil {
+		ref := defaultModelRevision
+		if state.Model.Revision.ValueString() != "" {
+			ref = state.Model.Revision.ValueString()
+		}
+		hubModel, err := r.hub.getModel(ctx, state.Model.Repository, ref)
+		if err != nil {
+			tflog.Warn(ctx, "could not resolve the deployed model revision", map[string]any{"repository": state.Model.Repository, "error": err.Error()})
+		} else {
+			state.Model.ResolvedRevision = types.StringValue(hubModel.SHA)
+		}
+	}
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -420,9 +1980,35 @@ func (r *endpointResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	var state endpointResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// endpoints of an older state without namespace live in the namespace of the provider
+	if plan.Namespace.IsUnknown() {
+		plan.Namespace = types.StringValue(r.namespaces.defaultNamespace)
+	}
+	clients, err := r.clientsFor(plan.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"unable to create huggingface api client",
+			err.Error(),
+		)
+		return
+	}
+
 	endpoint := providerEndpointToUpdateEndpointRequest(plan)
 
-	updatedEndpoint, err := r.client.UpdateEndpoint(plan.Name.ValueString(), endpoint)
+	updatedEndpoint, err := clients.api.updateEndpoint(ctx, plan.Name.ValueString(), endpoint)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"error updating endpoint",
@@ -431,7 +2017,22 @@ func (r *endpointResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	plan = clientEndpointToProviderEndpoint(updatedEndpoint)
+	updatedEndpoint = r.waitForEndpointReady(ctx, clients, updateTimeout, updatedEndpoint, &resp.Diagnostics)
+	if detail := r.checkEndpoint(ctx, clients, updatedEndpoint, plan.HealthCheck); detail != "" {
+		if plan.RollbackOnFailure.ValueBool() {
+			var outcome string
+			updatedEndpoint, outcome = r.rollbackEndpoint(ctx, clients, updateTimeout, state, updatedEndpoint)
+			detail += "\n\n" + outcome
+		}
+		resp.Diagnostics.AddError(
+			"endpoint failed",
+			detail,
+		)
+	}
+
+	newPlan := clientEndpointToProviderEndpoint(updatedEndpoint)
+	copyProviderOnlyAttributes(&newPlan, plan)
+	plan = newPlan
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -448,12 +2049,227 @@ func (r *endpointResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	err := r.client.DeleteEndpoint(state.Name.ValueString())
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clients, err := r.clientsFor(state.Namespace.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"error deleting endpoint",
+			"unable to create huggingface api client",
 			err.Error(),
 		)
 		return
 	}
+
+	err = r.deleteEndpoint(ctx, clients, state.Name.ValueString(), deleteTimeout)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error deleting endpoint",
+			"could not delete endpoint named "+state.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// endpointClients are the clients of the namespace an endpoint lives in.
+type endpointClients struct {
+	client *huggingface.Client
+	api    *apiClient
+}
+
+// clientsFor returns the clients of namespace, or of the namespace of the provider when it is
+// empty.
+func (r *endpointResource) clientsFor(namespace string) (endpointClients, error) {
+	client, api, err := r.namespaces.get(namespace)
+	return endpointClients{client: client, api: api}, err
+}
+
+// deleteEndpoint deletes the named endpoint and waits until it is gone. An endpoint that is
+// already gone counts as deleted.
+func (r *endpointResource) deleteEndpoint(ctx context.Context, clients endpointClients, name string, timeout time.Duration) error {
+	err := clients.client.DeleteEndpoint(name)
+	if err != nil {
+		exists, existsErr := r.endpointExists(clients, name)
+		if existsErr != nil || exists {
+			return err
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return r.waitForEndpointDeletion(ctx, clients, name)
+}
+
+// endpointExists reports whether an endpoint with the given name exists in the namespace.
+func (r *endpointResource) endpointExists(clients endpointClients, name string) (bool, error) {
+	existingEndpoints, err := clients.client.ListEndpoints()
+	if err != nil {
+		return false, err
+	}
+
+	for _, existingEndpoint := range existingEndpoints {
+		if existingEndpoint.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// waitForEndpointDeletion polls the API until the endpoint no longer exists or ctx is done.
+func (r *endpointResource) waitForEndpointDeletion(ctx context.Context, clients endpointClients, name string) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		exists, err := r.endpointExists(clients, name)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForEndpointReady polls the endpoint until it is ready or has failed, reporting a timeout
+// in diags. It returns the last known details of the endpoint.
+func (r *endpointResource) waitForEndpointReady(ctx context.Context, clients endpointClients, timeout time.Duration, endpoint endpointDetails, diags *diag.Diagnostics) endpointDetails {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		switch endpoint.Status.State {
+		case endpointStateRunning, endpointStateScaledToZero, endpointStatePaused,
+			endpointStateFailed, endpointStateUpdateFailed:
+			return endpoint
+		}
+
+		select {
+		case <-waitCtx.Done():
+			diags.AddError(
+				"error waiting for endpoint",
+				"endpoint named "+endpoint.Name+" did not become ready: "+waitCtx.Err().Error(),
+			)
+			return endpoint
+		case <-ticker.C:
+		}
+
+		latest, err := clients.api.getEndpoint(ctx, endpoint.Name)
+		if err != nil {
+			diags.AddError(
+				"error reading endpoint",
+				"could not read endpoint named "+endpoint.Name+": "+err.Error(),
+			)
+			return endpoint
+		}
+		endpoint = latest
+	}
+}
+
+// rollbackEndpoint re-applies the prior configuration of an endpoint whose update failed and
+// describes the outcome. It returns the last known details of the endpoint.
+func (r *endpointResource) rollbackEndpoint(ctx context.Context, clients endpointClients, timeout time.Duration, prior endpointResourceModel, failed endpointDetails) (endpointDetails, string) {
+	tflog.Info(ctx, "rolling back endpoint", map[string]any{"name": prior.Name.ValueString()})
+
+	rolledBack, err := clients.api.updateEndpoint(ctx, prior.Name.ValueString(), providerEndpointToUpdateEndpointRequest(prior))
+	if err != nil {
+		return failed, "could not roll back the endpoint to its previous configuration: " + err.Error()
+	}
+
+	var diags diag.Diagnostics
+	rolledBack = r.waitForEndpointReady(ctx, clients, timeout, rolledBack, &diags)
+	if diags.HasError() {
+		return rolledBack, "rollback of the endpoint to its previous configuration did not complete: " + diags.Errors()[0].Detail()
+	}
+	if detail := r.checkEndpoint(ctx, clients, rolledBack, prior.HealthCheck); detail != "" {
+		return rolledBack, "rollback of the endpoint to its previous configuration failed: " + detail
+	}
+	return rolledBack, "the endpoint has been rolled back to its previous configuration"
+}
+
+// checkEndpoint describes why an endpoint that is done waiting cannot serve, or returns an
+// empty string when it failed neither to start nor the health check.
+func (r *endpointResource) checkEndpoint(ctx context.Context, clients endpointClients, endpoint endpointDetails, healthCheck *HealthCheck) string {
+	if endpointFailed(endpoint) {
+		return r.endpointFailureDetail(ctx, clients, endpoint)
+	}
+	if healthCheck == nil || (endpoint.Status.State != endpointStateRunning && endpoint.Status.State != endpointStateScaledToZero) {
+		return ""
+	}
+
+	err := r.probeEndpoint(ctx, clients, endpoint, *healthCheck)
+	if err != nil {
+		return "health check of endpoint named " + endpoint.Name + " failed: " + err.Error()
+	}
+	return ""
+}
+
+// probeEndpoint sends the health check request to the endpoint URL until it answers with the
+// expected status code or the retries are exhausted.
+func (r *endpointResource) probeEndpoint(ctx context.Context, clients endpointClients, endpoint endpointDetails, healthCheck HealthCheck) error {
+	probeURL := strings.TrimSuffix(endpoint.Status.URL, "/") + "/" + strings.TrimPrefix(healthCheck.Path, "/")
+
+	var err error
+	for attempt := 0; attempt <= healthCheck.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(pollInterval):
+			}
+		}
+
+		var body io.Reader
+		if healthCheck.Body != nil {
+			body = strings.NewReader(*healthCheck.Body)
+		}
+
+		var statusCode int
+		var respBody []byte
+		statusCode, respBody, err = clients.api.send(ctx, healthCheck.Method, probeURL, body)
+		if err == nil && statusCode == healthCheck.ExpectedStatus {
+			return nil
+		}
+		if err == nil {
+			err = fmt.Errorf("expected status code %d, got %d: %s", healthCheck.ExpectedStatus, statusCode, respBody)
+		}
+		tflog.Debug(ctx, "endpoint health check attempt failed", map[string]any{"name": endpoint.Name, "attempt": attempt, "error": err.Error()})
+	}
+	return err
+}
+
+func endpointFailed(endpoint endpointDetails) bool {
+	return endpoint.Status.State == endpointStateFailed || endpoint.Status.State == endpointStateUpdateFailed
+}
+
+// endpointFailureDetail describes a failed endpoint, including the tail of its container logs.
+func (r *endpointResource) endpointFailureDetail(ctx context.Context, clients endpointClients, endpoint endpointDetails) string {
+	detail := fmt.Sprintf("endpoint named %s is in state %s: %s", endpoint.Name, endpoint.Status.State, endpoint.Status.ErrorMessage)
+	if r.failureLogLines == 0 {
+		return detail
+	}
+
+	logs, err := clients.api.endpointLogs(ctx, endpoint.Name, r.failureLogLines)
+	if err != nil {
+		tflog.Warn(ctx, "could not fetch endpoint logs", map[string]any{"name": endpoint.Name, "error": err.Error()})
+		return detail
+	}
+	if logs == "" {
+		return detail
+	}
+	return detail + "\n\nendpoint logs:\n" + logs
 }
@@ -0,0 +1,361 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testEndpointResource returns an endpoint resource whose Hub and endpoints API are served by a
+// test server knowing the model org/model and a single aws us-east-1 instance at $1/h.
+func testEndpointResource(t *testing.T) *endpointResource {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body any
+		switch r.URL.Path {
+		case "/api/models/org/model/revision/main":
+			body = map[string]any{"id": "org/model", "sha": "mainsha", "pipeline_tag": "text-generation", "library_name": "transformers"}
+		case "/api/models/org/model/revision/v1.0":
+			body = map[string]any{"id": "org/model", "sha": "v1sha", "pipeline_tag": "text-generation", "library_name": "transformers"}
+		case "/provider/aws/regions/us-east-1/compute":
+			body = map[string]any{"items": []computeInstance{
+				{Accelerator: "gpu", InstanceType: "nvidia-a10g", InstanceSize: "x1", NumGpus: 1, GpuMemoryGb: 24, PricePerHour: 1},
+			}}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			t.Error(err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	api := &apiClient{host: server.URL + "/endpoint", namespace: "team", token: "token", httpClient: server.Client()}
+	return &endpointResource{
+		httpClient: server.Client(),
+		hub:        &apiClient{host: server.URL, token: "token", httpClient: server.Client()},
+		namespaces: &namespaceClients{host: api.host, token: api.token, defaultNamespace: "team", httpClient: server.Client()},
+		budget:     &budget{costs: map[string]endpointCost{}, replaced: map[string][]string{}},
+		catalogues: &computeCatalogueCache{api: api, catalogues: map[string][]computeInstance{}},
+	}
+}
+
+// testEndpointModel returns the configuration of an endpoint with every computed attribute null.
+func testEndpointModel() endpointResourceModel {
+	return endpointResourceModel{
+		Compute: Compute{
+			Accelerator:  "gpu",
+			InstanceSize: "x1",
+			InstanceType: "nvidia-a10g",
+			Scaling:      Scaling{MaxReplica: 1},
+		},
+		Model: Model{
+			Framework:     types.StringValue("pytorch"),
+			Image:         Image{Huggingface: &Huggingface{}},
+			Repository:    "org/model",
+			Task:          types.StringValue("text-generation"),
+			TrackRevision: types.BoolValue(false),
+		},
+		Name:              types.StringValue("web"),
+		Namespace:         types.StringValue("team"),
+		Cloud:             Cloud{Region: "us-east-1", Vendor: "aws"},
+		Type:              types.StringValue(endpointTypeProtected),
+		OnFailure:         types.StringValue(onFailureTaint),
+		RollbackOnFailure: types.BoolValue(false),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{
+				"create": types.StringType,
+				"update": types.StringType,
+				"delete": types.StringType,
+			}),
+		},
+	}
+}
+
+// modifyPlan runs ModifyPlan on the plan of config, starting from state when it is not nil.
+func modifyPlan(t *testing.T, r *endpointResource, config endpointResourceModel, state *endpointResourceModel) resource.ModifyPlanResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	value := func(model *endpointResourceModel) tftypes.Value {
+		raw := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+		if model == nil {
+			return raw.Raw
+		}
+		if diags := raw.Set(ctx, model); diags.HasError() {
+			t.Fatalf("could not build value: %v", diags)
+		}
+		return raw.Raw
+	}
+
+	// computed attributes omitted from the configuration are unknown, unless their state is used
+	plan := config
+	plan.Model.ResolvedRevision = types.StringUnknown()
+	if config.Name.IsNull() {
+		plan.Name = types.StringUnknown()
+	}
+	if config.Namespace.IsNull() {
+		plan.Namespace = types.StringUnknown()
+	}
+	if state != nil {
+		plan.Model.ResolvedRevision = state.Model.ResolvedRevision
+		if config.Name.IsNull() {
+			plan.Name = state.Name
+		}
+		if config.Namespace.IsNull() && !state.Namespace.IsNull() {
+			plan.Namespace = state.Namespace
+		}
+	}
+
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: value(&config)},
+		State:  tfsdk.State{Schema: s, Raw: value(state)},
+		Plan:   tfsdk.Plan{Schema: s, Raw: value(&plan)},
+	}
+	resp := resource.ModifyPlanResponse{Plan: req.Plan}
+	r.ModifyPlan(ctx, req, &resp)
+	return resp
+}
+
+// assertError checks that diags hold only an error with summary at attributePath, or no error at
+// all when summary is empty.
+func assertError(t *testing.T, diags diag.Diagnostics, attributePath path.Path, summary string) {
+	t.Helper()
+
+	errs := diags.Errors()
+	if summary == "" {
+		if len(errs) != 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+		return
+	}
+	if len(errs) != 1 {
+		t.Fatalf("errors = %v, want %q at %s", errs, summary, attributePath)
+	}
+	withPath, ok := errs[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(attributePath) || errs[0].Summary() != summary {
+		t.Fatalf("error = %v, want %q at %s", errs[0], summary, attributePath)
+	}
+}
+
+func TestModifyPlanRevision(t *testing.T) {
+	repository := path.Root("model").AtName("repository")
+
+	tests := []struct {
+		name       string
+		repository string
+		revision   types.String
+		state      string
+		want       string
+		wantErr    string
+	}{
+		{name: "default revision", repository: "org/model", revision: types.StringNull(), want: "mainsha"},
+		{name: "revision", repository: "org/model", revision: types.StringValue("v1.0"), want: "v1sha"},
+		{name: "revision in state", repository: "org/model", revision: types.StringNull(), state: "oldsha", want: "oldsha"},
+		{name: "missing repository", repository: "org/missing", revision: types.StringNull(), wantErr: "error resolving model revision"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testEndpointModel()
+			config.Model.Repository = test.repository
+			config.Model.Revision = test.revision
+
+			var state *endpointResourceModel
+			if test.state != "" {
+				prior := config
+				prior.Model.ResolvedRevision = types.StringValue(test.state)
+				state = &prior
+			}
+
+			resp := modifyPlan(t, testEndpointResource(t), config, state)
+			assertError(t, resp.Diagnostics, repository, test.wantErr)
+			if test.wantErr != "" {
+				return
+			}
+
+			var got types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(context.Background(), repository.ParentPath().AtName("resolved_revision"), &got)...)
+			if got.ValueString() != test.want {
+				t.Errorf("resolved_revision = %s, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestModifyPlanName(t *testing.T) {
+	tests := []struct {
+		name        string
+		stateName   string
+		stateNs     types.String
+		stateCloud  Cloud
+		namePrefix  bool
+		wantWarning bool
+	}{
+		{
+			name:       "unchanged",
+			stateName:  "web",
+			stateNs:    types.StringValue("team"),
+			stateCloud: Cloud{Region: "us-east-1", Vendor: "aws"},
+		},
+		{
+			name:        "replaced under the same name",
+			stateName:   "web",
+			stateNs:     types.StringValue("team"),
+			stateCloud:  Cloud{Region: "eu-west-1", Vendor: "aws"},
+			wantWarning: true,
+		},
+		{
+			name:        "replaced under the same name in an older state without namespace",
+			stateName:   "web",
+			stateNs:     types.StringNull(),
+			stateCloud:  Cloud{Region: "eu-west-1", Vendor: "aws"},
+			wantWarning: true,
+		},
+		{
+			name:       "replaced under another name",
+			stateName:  "web-old",
+			stateNs:    types.StringValue("team"),
+			stateCloud: Cloud{Region: "eu-west-1", Vendor: "aws"},
+		},
+		{
+			name:       "replaced in another namespace",
+			stateName:  "web",
+			stateNs:    types.StringValue("other"),
+			stateCloud: Cloud{Region: "eu-west-1", Vendor: "aws"},
+		},
+		{
+			name:       "replaced with a generated name",
+			stateName:  "web-1234abcd",
+			stateNs:    types.StringValue("team"),
+			stateCloud: Cloud{Region: "eu-west-1", Vendor: "aws"},
+			namePrefix: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testEndpointModel()
+			state := testEndpointModel()
+			state.Name = types.StringValue(test.stateName)
+			state.Namespace = test.stateNs
+			state.Cloud = test.stateCloud
+			state.Model.ResolvedRevision = types.StringValue("mainsha")
+			if test.stateNs.IsNull() {
+				config.Namespace = types.StringNull()
+			}
+			if test.namePrefix {
+				config.Name = types.StringNull()
+				config.NamePrefix = types.StringValue("web-")
+				state.NamePrefix = config.NamePrefix
+			}
+
+			resp := modifyPlan(t, testEndpointResource(t), config, &state)
+			assertError(t, resp.Diagnostics, path.Root("name"), "")
+
+			warnings := resp.Diagnostics.Warnings()
+			if !test.wantWarning {
+				if len(warnings) != 0 {
+					t.Fatalf("unexpected warnings: %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || warnings[0].Summary() != "endpoint replaced under the same name" {
+				t.Fatalf("warnings = %v, want %q", warnings, "endpoint replaced under the same name")
+			}
+		})
+	}
+}
+
+func TestModifyPlanBudget(t *testing.T) {
+	compute := path.Root("compute")
+
+	t.Run("endpoint limit", func(t *testing.T) {
+		r := testEndpointResource(t)
+		limit := 1.5
+		r.budget.maxPerEndpoint = &limit
+
+		config := testEndpointModel()
+		config.Compute.Scaling.MaxReplica = 2
+		resp := modifyPlan(t, r, config, nil)
+		assertError(t, resp.Diagnostics, compute, "endpoint exceeds hourly cost limit")
+	})
+
+	t.Run("total limit", func(t *testing.T) {
+		r := testEndpointResource(t)
+		limit := 2.5
+		r.budget.maxTotal = &limit
+
+		first := testEndpointModel()
+		first.Compute.Scaling.MaxReplica = 2
+		assertError(t, modifyPlan(t, r, first, nil).Diagnostics, compute, "")
+
+		second := testEndpointModel()
+		second.Name = types.StringValue("api")
+		resp := modifyPlan(t, r, second, nil)
+		assertError(t, resp.Diagnostics, compute, "endpoints exceed total hourly cost limit")
+	})
+
+	t.Run("count with name_prefix", func(t *testing.T) {
+		r := testEndpointResource(t)
+		limit := 2.5
+		r.budget.maxTotal = &limit
+
+		config := testEndpointModel()
+		config.Name = types.StringNull()
+		config.NamePrefix = types.StringValue("web-")
+
+		// the instances of the resource are planned without state with the same attributes
+		assertError(t, modifyPlan(t, r, config, nil).Diagnostics, compute, "")
+		assertError(t, modifyPlan(t, r, config, nil).Diagnostics, compute, "")
+		resp := modifyPlan(t, r, config, nil)
+		assertError(t, resp.Diagnostics, compute, "endpoints exceed total hourly cost limit")
+	})
+
+	for _, test := range []struct {
+		name       string
+		stateCloud Cloud
+		wantErr    string
+	}{
+		{name: "replacement", stateCloud: Cloud{Region: "eu-west-1", Vendor: "aws"}},
+		{name: "new endpoint next to one in state", stateCloud: Cloud{Region: "us-east-1", Vendor: "aws"}, wantErr: "endpoints exceed total hourly cost limit"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			r := testEndpointResource(t)
+			limit := 1.5
+			r.budget.maxTotal = &limit
+
+			config := testEndpointModel()
+			config.Name = types.StringNull()
+			config.NamePrefix = types.StringValue("web-")
+
+			state := testEndpointModel()
+			state.Name = types.StringValue("web-1234abcd")
+			state.NamePrefix = types.StringValue("web-")
+			state.Cloud = test.stateCloud
+			state.Model.ResolvedRevision = types.StringValue("mainsha")
+
+			// the create of a replacement is planned without state after the endpoint it replaces
+			assertError(t, modifyPlan(t, r, config, &state).Diagnostics, compute, "")
+			assertError(t, modifyPlan(t, r, config, nil).Diagnostics, compute, test.wantErr)
+		})
+	}
+}